@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxRegression is the factor by which a benchmark may be slower than its baseline.
+const maxRegression = 1.2
+
+// benchcheck compares the output of go test -bench against a baseline in the same format.
+// It fails if any benchmark of the baseline is missing or more than 20% slower.
+// If a benchmark ran multiple times the fastest run is used.
+func main() {
+	if len(os.Args) != 3 {
+		log.Fatal("usage: benchcheck <baseline> <current>")
+		return
+	}
+
+	baseline, err := readBenchmarksFile(os.Args[1])
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	current, err := readBenchmarksFile(os.Args[2])
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	if regressions := compare(baseline, current); len(regressions) > 0 {
+		for _, r := range regressions {
+			log.Print(r)
+		}
+
+		os.Exit(1)
+	}
+}
+
+func readBenchmarksFile(name string) (map[string]float64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	benchmarks, err := readBenchmarks(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return benchmarks, nil
+}
+
+// readBenchmarks returns the fastest ns/op per benchmark name, without the GOMAXPROCS suffix.
+func readBenchmarks(r io.Reader) (map[string]float64, error) {
+	benchmarks := make(map[string]float64)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+
+		idx := slices.Index(fields, "ns/op")
+		if idx < 1 {
+			continue
+		}
+
+		nsPerOp, err := strconv.ParseFloat(fields[idx-1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ns/op in %q: %w", scanner.Text(), err)
+		}
+
+		name := fields[0]
+		if i := strings.LastIndexByte(name, '-'); i > 0 {
+			if _, err := strconv.Atoi(name[i+1:]); err == nil {
+				name = name[:i]
+			}
+		}
+
+		if prev, ok := benchmarks[name]; !ok || nsPerOp < prev {
+			benchmarks[name] = nsPerOp
+		}
+	}
+
+	return benchmarks, scanner.Err()
+}
+
+func compare(baseline, current map[string]float64) []string {
+	var regressions []string
+	for name, base := range baseline {
+		cur, ok := current[name]
+		if !ok {
+			regressions = append(regressions, fmt.Sprintf("%s: missing from current results", name))
+		} else if cur > base*maxRegression {
+			regressions = append(regressions, fmt.Sprintf("%s: %.0f ns/op is %.0f%% slower than the baseline of %.0f ns/op", name, cur, (cur/base-1)*100, base))
+		}
+	}
+
+	slices.Sort(regressions)
+	return regressions
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	baseline, err := readBenchmarks(strings.NewReader("goos: linux\nBenchmarkA-8   100   1000 ns/op   10 B/op   1 allocs/op\nBenchmarkB-8   100   1000 ns/op\nBenchmarkC-8   100   1000 ns/op\nBenchmarkD/x-y   100   1000 ns/op\nPASS\n"))
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	current, err := readBenchmarks(strings.NewReader("BenchmarkA-4   100   1100 ns/op\nBenchmarkD/x-y   100   900 ns/op\nBenchmarkB-4   100   1300 ns/op\nBenchmarkB-4   100   1500 ns/op\n"))
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	regressions := compare(baseline, current)
+	if len(regressions) != 2 || !strings.HasPrefix(regressions[0], "BenchmarkB:") || !strings.HasPrefix(regressions[1], "BenchmarkC:") {
+		t.Fatalf("expected regressions for BenchmarkB and BenchmarkC, got %v", regressions)
+		return
+	}
+}
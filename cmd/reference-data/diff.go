@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"github.com/explore-flights/reference-data/pkg/referencedata/cmd"
+	"io"
+	"os"
+)
+
+// runDiff implements the diff subcommand, which reports the differences between two directories of csv files.
+func runDiff(_ context.Context, args []string) error {
+	flags := flag.NewFlagSet("reference-data diff", flag.ContinueOnError)
+	baseDir := flags.String("base", "", "directory containing the base csv files")
+	headDir := flags.String("head", "", "directory containing the head csv files")
+	common := cmd.CommonFlags{Format: "text"}
+	common.RegisterFormat(flags, "output format, one of text or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *baseDir == "" || *headDir == "" {
+		return fmt.Errorf("both --base and --head are required")
+	}
+
+	if common.Format != "text" && common.Format != "json" {
+		return fmt.Errorf("unsupported format %q", common.Format)
+	}
+
+	base, err := referencedata.NewRegistryFromDir(*baseDir)
+	if err != nil {
+		return fmt.Errorf("failed to load base: %w", err)
+	}
+
+	head, err := referencedata.NewRegistryFromDir(*headDir)
+	if err != nil {
+		return fmt.Errorf("failed to load head: %w", err)
+	}
+
+	diffs := referencedata.DiffRegistries(base, head)
+	if common.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diffs)
+	}
+
+	return writeDiffText(os.Stdout, diffs)
+}
+
+func writeDiffText(w io.Writer, diffs []referencedata.TableDiff) error {
+	for _, diff := range diffs {
+		if _, err := fmt.Fprintf(w, "%s: %d added, %d removed, %d changed\n", diff.Table, len(diff.Added), len(diff.Removed), len(diff.Changed)); err != nil {
+			return err
+		}
+
+		for _, key := range diff.Added {
+			if _, err := fmt.Fprintf(w, "  + %s\n", key); err != nil {
+				return err
+			}
+		}
+
+		for _, key := range diff.Removed {
+			if _, err := fmt.Fprintf(w, "  - %s\n", key); err != nil {
+				return err
+			}
+		}
+
+		for _, change := range diff.Changed {
+			if _, err := fmt.Fprintf(w, "  ~ %s\n", change.Key); err != nil {
+				return err
+			}
+
+			for _, field := range change.Fields {
+				if _, err := fmt.Fprintf(w, "      %s: %q -> %q\n", field.Field, field.Base, field.Head); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"strings"
+	"testing"
+)
+
+func TestWriteDiffText(t *testing.T) {
+	diffs := []referencedata.TableDiff{{
+		Table:   "aircraft_types",
+		Added:   []string{"321"},
+		Removed: []string{"100"},
+		Changed: []referencedata.RowChange{{Key: "320", Fields: []referencedata.FieldChange{{Field: "name", Base: "Airbus A320", Head: "Airbus A320ceo"}}}},
+	}}
+
+	var buf bytes.Buffer
+	if err := writeDiffText(&buf, diffs); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, expected := range []string{"aircraft_types: 1 added, 1 removed, 1 changed", "  + 321", "  - 100", "      name: \"Airbus A320\" -> \"Airbus A320ceo\""} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatalf("expected %q in text output:\n%s", expected, buf.String())
+			return
+		}
+	}
+}
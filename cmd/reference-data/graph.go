@@ -0,0 +1,503 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"github.com/goccy/go-graphviz"
+	"io"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// errRenderTimeout is returned by render if the timeout elapsed before rendering finished.
+var errRenderTimeout = errors.New("render timed out")
+
+// renderer is implemented by *graphviz.Graphviz.
+type renderer interface {
+	Render(ctx context.Context, graph *graphviz.Graph, format graphviz.Format, w io.Writer) error
+}
+
+// bodyTypeColors maps body types to the fill color of their aircraft type nodes.
+var bodyTypeColors = map[referencedata.BodyType]string{
+	referencedata.BodyTypeWidebody:    "#f4a582",
+	referencedata.BodyTypeNarrowbody:  "#92c5de",
+	referencedata.BodyTypeRegionalJet: "#d1e5f0",
+	referencedata.BodyTypeBusinessJet: "#b2abd2",
+	referencedata.BodyTypeTurboprop:   "#a6dba0",
+	referencedata.BodyTypePiston:      "#fee08b",
+	referencedata.BodyTypeHelicopter:  "#fdb863",
+	referencedata.BodyTypeSurface:     "#bababa",
+}
+
+// defaultLabelTemplate is the template of the aircraft type node labels if none is given.
+const defaultLabelTemplate = "Aircraft\n{{.Name}}\nIATA: {{.IATA}}\nICAO: {{.ICAO}}"
+
+var defaultLabel = template.Must(parseLabelTemplate(defaultLabelTemplate))
+
+// parseLabelTemplate parses the template of the aircraft type node labels, which is executed with an *AircraftType.
+// The template is executed once with an empty aircraft type so that references to unknown fields fail early.
+func parseLabelTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("label").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label template: %w", err)
+	}
+
+	if err := tmpl.Execute(io.Discard, &referencedata.AircraftType{}); err != nil {
+		return nil, fmt.Errorf("invalid label template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+func colorForBodyType(bt referencedata.BodyType) string {
+	if color, ok := bodyTypeColors[bt]; ok {
+		return color
+	}
+
+	return "#ffffff"
+}
+
+// graphMode selects which records of the graph are rendered.
+type graphMode string
+
+const (
+	// graphModeFull renders all records.
+	graphModeFull graphMode = "full"
+	// graphModeFamilies renders families and aircraft types without aliases and variants.
+	graphModeFamilies graphMode = "families"
+	// graphModeAliases renders aliases and the records they point to, without the family hierarchy and variants.
+	graphModeAliases graphMode = "aliases"
+)
+
+// graphModes lists all graph modes.
+var graphModes = []graphMode{graphModeFull, graphModeFamilies, graphModeAliases}
+
+type graphOptions struct {
+	// family restricts the graph to the subtree of the family with this id, if set.
+	family string
+	// depth restricts the graph to families at most this many levels below a root family and their aircraft types, if positive.
+	// Root families are at level 1.
+	depth int
+	// labelTemplate renders the labels of aircraft type nodes, defaultLabel is used if nil.
+	labelTemplate *template.Template
+	// mode selects which records are rendered, an empty mode renders all records.
+	mode graphMode
+	// sortByKey inserts the nodes sorted by their primary key instead of in file order.
+	sortByKey bool
+}
+
+// dotSource returns the DOT source of the graph of all records of reg.
+// The nodes are inserted sorted by their primary key, so the same data always results in the same output.
+func dotSource(reg *referencedata.Registry) (string, error) {
+	ctx := context.Background()
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer g.Close()
+
+	graph, err := buildGraph(ctx, g, reg, graphOptions{sortByKey: true})
+	if err != nil {
+		return "", err
+	}
+	defer graph.Close()
+
+	var buf strings.Builder
+	if err := g.Render(ctx, graph, graphviz.XDOT, &buf); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// renderToBytes renders the graph of all records of reg with the given style in format.
+func renderToBytes(ctx context.Context, reg *referencedata.Registry, style GraphStyle, format graphviz.Format) ([]byte, error) {
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer g.Close()
+
+	graph, err := buildGraphWithStyle(ctx, g, reg, graphOptions{}, style)
+	if err != nil {
+		return nil, err
+	}
+	defer graph.Close()
+
+	var buf bytes.Buffer
+	if err := g.Render(ctx, graph, format, &buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func buildGraph(ctx context.Context, g *graphviz.Graphviz, reg *referencedata.Registry, opts graphOptions) (*graphviz.Graph, error) {
+	return buildGraphWithStyle(ctx, g, reg, opts, defaultGraphStyle)
+}
+
+// buildGraphWithStyle is buildGraph with the appearance of the graph configured by style.
+func buildGraphWithStyle(ctx context.Context, g *graphviz.Graphviz, reg *referencedata.Registry, opts graphOptions, style GraphStyle) (*graphviz.Graph, error) {
+	aircraftTypes, aircraftFamilies, aircraftAliases, err := graphRecords(reg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	graph, err := g.Graph()
+	if err != nil {
+		return nil, err
+	}
+
+	labelTemplate := opts.labelTemplate
+	if labelTemplate == nil {
+		labelTemplate = defaultLabel
+	}
+
+	if style.RankDir != "" {
+		graph.SetRankDir(style.RankDir)
+	}
+
+	var id graphviz.ID
+	aircraftNodeById := make(map[string]*graphviz.Node)
+	familyNodeById := make(map[string]*graphviz.Node)
+	clusterByManufacturerId := make(map[string]*graphviz.Graph)
+
+	for _, aircraftType := range aircraftTypes {
+		parent := graph
+		if manufacturer, ok := reg.Manufacturer(aircraftType); ok {
+			cluster, ok := clusterByManufacturerId[manufacturer.Id]
+			if !ok {
+				cluster, err = graph.CreateSubGraphByName("cluster_" + manufacturer.Id)
+				if err != nil {
+					return nil, err
+				}
+
+				cluster.SetLabel(manufacturer.CommercialName())
+				clusterByManufacturerId[manufacturer.Id] = cluster
+			}
+
+			parent = cluster
+		}
+
+		id++
+		node, err := parent.CreateNodeByName(strconv.FormatUint(uint64(id), 16))
+		if err != nil {
+			return nil, err
+		}
+
+		var label strings.Builder
+		if err := labelTemplate.Execute(&label, aircraftType); err != nil {
+			return nil, fmt.Errorf("failed to render label of aircraft type %q: %w", aircraftType.Id, err)
+		}
+
+		node.SetLabel(label.String())
+		style.applyToType(node, aircraftType)
+		aircraftNodeById[aircraftType.Id] = node
+
+		var variants []*referencedata.AircraftVariant
+		if opts.mode == "" || opts.mode == graphModeFull {
+			variants = reg.TypeVariants(aircraftType.Id)
+		}
+
+		if opts.sortByKey {
+			variants = sortedByKey(variants, func(v *referencedata.AircraftVariant) string { return v.Id })
+		}
+
+		for _, variant := range variants {
+			id++
+			variantNode, err := parent.CreateNodeByName(strconv.FormatUint(uint64(id), 16))
+			if err != nil {
+				return nil, err
+			}
+
+			variantNode.SetLabel(fmt.Sprintf("Variant\n%s\nICAO: %s", variant.Name, variant.ICAO))
+			variantNode.SetShape(graphviz.BoxShape)
+
+			id++
+			if _, err := graph.CreateEdgeByName(strconv.FormatUint(uint64(id), 16), node, variantNode); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, aircraftFamily := range aircraftFamilies {
+		id++
+		node, err := graph.CreateNodeByName(strconv.FormatUint(uint64(id), 16))
+		if err != nil {
+			return nil, err
+		}
+
+		node.SetLabel(fmt.Sprintf("Family\n%s\nIATA: %s", aircraftFamily.CommercialName(), aircraftFamily.IATA))
+		style.applyToFamily(node)
+		familyNodeById[aircraftFamily.Id] = node
+	}
+
+	for _, aircraftAlias := range aircraftAliases {
+		var targetId string
+		var targetNode *graphviz.Node
+		if aircraftTypeId := aircraftAlias.AircraftTypeId; aircraftTypeId != "" {
+			targetId = aircraftTypeId
+			targetNode = aircraftNodeById[aircraftTypeId]
+		} else if aircraftFamilyId := aircraftAlias.AircraftFamilyId; aircraftFamilyId != "" {
+			targetId = aircraftFamilyId
+			targetNode = familyNodeById[aircraftFamilyId]
+		}
+
+		if targetNode == nil {
+			log.Printf("warning: missing node for id %q at alias %q", targetId, aircraftAlias.Alias)
+			continue
+		}
+
+		id++
+		node, err := graph.CreateNodeByName(strconv.FormatUint(uint64(id), 16))
+		if err != nil {
+			return nil, err
+		}
+
+		node.SetLabel(fmt.Sprintf("Alias\nIATA: %s", aircraftAlias.Alias))
+		style.applyToAlias(node)
+
+		id++
+		_, err = graph.CreateEdgeByName(strconv.FormatUint(uint64(id), 16), node, targetNode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.mode == graphModeAliases {
+		return graph, nil
+	}
+
+	for _, aircraftType := range aircraftTypes {
+		srcNode, ok := familyNodeById[aircraftType.FamilyId]
+		if !ok {
+			continue
+		}
+
+		id++
+		_, err := graph.CreateEdgeByName(strconv.FormatUint(uint64(id), 16), srcNode, aircraftNodeById[aircraftType.Id])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, aircraftFamily := range aircraftFamilies {
+		srcNode, ok := familyNodeById[aircraftFamily.ParentFamilyId]
+		if !ok {
+			continue
+		}
+
+		id++
+		_, err := graph.CreateEdgeByName(strconv.FormatUint(uint64(id), 16), srcNode, familyNodeById[aircraftFamily.Id])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return graph, nil
+}
+
+// graphRecords returns the records of reg which are rendered with the given options, in file order unless sorted by key.
+func graphRecords(reg *referencedata.Registry, opts graphOptions) ([]*referencedata.AircraftType, []*referencedata.AircraftFamily, []*referencedata.AircraftAlias, error) {
+	aircraftTypes, aircraftFamilies, aircraftAliases, err := familyRecords(reg, opts.family)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if opts.depth > 0 {
+		aircraftTypes, aircraftFamilies, aircraftAliases = pruneDepth(aircraftTypes, aircraftFamilies, aircraftAliases, opts.depth)
+	}
+
+	switch opts.mode {
+	case graphModeFamilies:
+		aircraftAliases = nil
+
+	case graphModeAliases:
+		aircraftTypes, aircraftFamilies = aliasTargets(aircraftTypes, aircraftFamilies, aircraftAliases)
+	}
+
+	if opts.sortByKey {
+		aircraftTypes = sortedByKey(aircraftTypes, func(t *referencedata.AircraftType) string { return t.Id })
+		aircraftFamilies = sortedByKey(aircraftFamilies, func(f *referencedata.AircraftFamily) string { return f.Id })
+		aircraftAliases = sortedByKey(aircraftAliases, func(a *referencedata.AircraftAlias) string { return string(a.Alias) })
+	}
+
+	return aircraftTypes, aircraftFamilies, aircraftAliases, nil
+}
+
+// aliasTargets returns the aircraft types and families which are the target of at least one of aircraftAliases, in the given order.
+func aliasTargets(aircraftTypes []*referencedata.AircraftType, aircraftFamilies []*referencedata.AircraftFamily, aircraftAliases []*referencedata.AircraftAlias) ([]*referencedata.AircraftType, []*referencedata.AircraftFamily) {
+	typeIds := make(map[string]struct{})
+	familyIds := make(map[string]struct{})
+	for _, a := range aircraftAliases {
+		if a.AircraftTypeId != "" {
+			typeIds[a.AircraftTypeId] = struct{}{}
+		} else if a.AircraftFamilyId != "" {
+			familyIds[a.AircraftFamilyId] = struct{}{}
+		}
+	}
+
+	var types []*referencedata.AircraftType
+	for _, t := range aircraftTypes {
+		if _, ok := typeIds[t.Id]; ok {
+			types = append(types, t)
+		}
+	}
+
+	var families []*referencedata.AircraftFamily
+	for _, f := range aircraftFamilies {
+		if _, ok := familyIds[f.Id]; ok {
+			families = append(families, f)
+		}
+	}
+
+	return types, families
+}
+
+// sortedByKey returns a copy of records sorted by key.
+func sortedByKey[T any](records []T, key func(T) string) []T {
+	return slices.SortedStableFunc(slices.Values(records), func(a, b T) int {
+		return strings.Compare(key(a), key(b))
+	})
+}
+
+// familyRecords returns the records of reg in the subtree of the family with the given id, or all records if id is empty.
+func familyRecords(reg *referencedata.Registry, id string) ([]*referencedata.AircraftType, []*referencedata.AircraftFamily, []*referencedata.AircraftAlias, error) {
+	if id == "" {
+		return reg.Types(), reg.Families(), reg.Aliases(), nil
+	}
+
+	descendants, descendantTypes, err := reg.FamilyDescendants(id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	familyIds := map[string]struct{}{id: {}}
+	for _, f := range descendants {
+		familyIds[f.Id] = struct{}{}
+	}
+
+	typeIds := make(map[string]struct{})
+	for _, t := range descendantTypes {
+		typeIds[t.Id] = struct{}{}
+	}
+
+	var aircraftTypes []*referencedata.AircraftType
+	for _, t := range reg.Types() {
+		if _, ok := typeIds[t.Id]; ok {
+			aircraftTypes = append(aircraftTypes, t)
+		}
+	}
+
+	var aircraftFamilies []*referencedata.AircraftFamily
+	for _, f := range reg.Families() {
+		if _, ok := familyIds[f.Id]; ok {
+			aircraftFamilies = append(aircraftFamilies, f)
+		}
+	}
+
+	var aircraftAliases []*referencedata.AircraftAlias
+	for _, a := range reg.Aliases() {
+		_, isType := typeIds[a.AircraftTypeId]
+		_, isFamily := familyIds[a.AircraftFamilyId]
+		if isType || isFamily {
+			aircraftAliases = append(aircraftAliases, a)
+		}
+	}
+
+	return aircraftTypes, aircraftFamilies, aircraftAliases, nil
+}
+
+// pruneDepth removes all families more than depth levels below a root family, the aircraft types of removed families
+// and the aliases of removed records. A family is a root if its parent is not part of aircraftFamilies.
+// Aircraft types without a family are kept.
+func pruneDepth(aircraftTypes []*referencedata.AircraftType, aircraftFamilies []*referencedata.AircraftFamily, aircraftAliases []*referencedata.AircraftAlias, depth int) ([]*referencedata.AircraftType, []*referencedata.AircraftFamily, []*referencedata.AircraftAlias) {
+	familyById := make(map[string]*referencedata.AircraftFamily, len(aircraftFamilies))
+	for _, f := range aircraftFamilies {
+		familyById[f.Id] = f
+	}
+
+	levels := make(map[string]int, len(aircraftFamilies))
+	var level func(f *referencedata.AircraftFamily, visited map[string]struct{}) int
+	level = func(f *referencedata.AircraftFamily, visited map[string]struct{}) int {
+		if l, ok := levels[f.Id]; ok {
+			return l
+		}
+
+		l := 1
+		if parent, ok := familyById[f.ParentFamilyId]; ok {
+			if _, cyclic := visited[f.Id]; !cyclic {
+				visited[f.Id] = struct{}{}
+				l = level(parent, visited) + 1
+			}
+		}
+
+		levels[f.Id] = l
+		return l
+	}
+
+	var families []*referencedata.AircraftFamily
+	familyIds := make(map[string]struct{})
+	for _, f := range aircraftFamilies {
+		if level(f, make(map[string]struct{})) <= depth {
+			families = append(families, f)
+			familyIds[f.Id] = struct{}{}
+		}
+	}
+
+	var types []*referencedata.AircraftType
+	typeIds := make(map[string]struct{})
+	for _, t := range aircraftTypes {
+		_, hasFamily := familyById[t.FamilyId]
+		_, included := familyIds[t.FamilyId]
+		if !hasFamily || included {
+			types = append(types, t)
+			typeIds[t.Id] = struct{}{}
+		}
+	}
+
+	var aliases []*referencedata.AircraftAlias
+	for _, a := range aircraftAliases {
+		_, isType := typeIds[a.AircraftTypeId]
+		_, isFamily := familyIds[a.AircraftFamilyId]
+		if isType || isFamily {
+			aliases = append(aliases, a)
+		}
+	}
+
+	return types, families, aliases
+}
+
+// render renders graph to w. A timeout of zero disables the timeout.
+// Once ctx is done render still waits for r to return, so graph, w and r stay in use until render returns.
+func render(ctx context.Context, r renderer, graph *graphviz.Graph, format graphviz.Format, w io.Writer, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Render(ctx, graph, format, w)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		<-done
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("rendering did not finish within %s: %w", timeout, errRenderTimeout)
+		}
+
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"github.com/goccy/go-graphviz"
+)
+
+// GraphStyle configures the appearance of the rendered graph. Empty fields keep the graphviz defaults,
+// except for TypeNodeColor which falls back to the color of the body type.
+type GraphStyle struct {
+	RankDir graphviz.RankDir
+	// TypeNodeColor is the fill color of all aircraft type nodes instead of the color of their body type.
+	TypeNodeColor   string
+	FamilyNodeColor string
+	AliasNodeColor  string
+	TypeNodeShape   graphviz.Shape
+	FamilyNodeShape graphviz.Shape
+}
+
+// defaultGraphStyle is the style of buildGraph.
+var defaultGraphStyle = GraphStyle{RankDir: graphviz.LRRank}
+
+func (s GraphStyle) applyToType(node *graphviz.Node, aircraftType *referencedata.AircraftType) {
+	color := s.TypeNodeColor
+	if color == "" {
+		color = colorForBodyType(aircraftType.BodyType)
+	}
+
+	fill(node, color)
+	if s.TypeNodeShape != "" {
+		node.SetShape(s.TypeNodeShape)
+	}
+}
+
+func (s GraphStyle) applyToFamily(node *graphviz.Node) {
+	fill(node, s.FamilyNodeColor)
+	if s.FamilyNodeShape != "" {
+		node.SetShape(s.FamilyNodeShape)
+	}
+}
+
+func (s GraphStyle) applyToAlias(node *graphviz.Node) {
+	fill(node, s.AliasNodeColor)
+}
+
+// fill sets the fill color of node unless color is empty.
+func fill(node *graphviz.Node, color string) {
+	if color != "" {
+		node.SetStyle(graphviz.FilledNodeStyle)
+		node.SetFillColor(color)
+	}
+}
@@ -0,0 +1,660 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"github.com/goccy/go-graphviz"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// blockingRenderer blocks until the context is done.
+type blockingRenderer struct{}
+
+func (blockingRenderer) Render(ctx context.Context, _ *graphviz.Graph, _ graphviz.Format, _ io.Writer) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestBuildGraphFamilySubtree(t *testing.T) {
+	ctx := context.Background()
+	reg, err := referencedata.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	fullGraph, err := buildGraph(ctx, g, reg, graphOptions{})
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	subGraph, err := buildGraph(ctx, g, reg, graphOptions{family: "7MX"})
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	fullNodes := graphNodes(t, fullGraph)
+	subNodes := graphNodes(t, subGraph)
+	if len(subNodes) == 0 || len(subNodes) >= len(fullNodes) {
+		t.Fatalf("expected subtree to have fewer nodes than the full graph (%d), got %d", len(fullNodes), len(subNodes))
+		return
+	}
+
+	f, _ := reg.Family("7MX")
+	var familyNode *graphviz.Node
+	for _, node := range subNodes {
+		if node.Label() == "Family\n"+f.Name+"\nIATA: "+string(f.IATA) {
+			familyNode = node
+		}
+	}
+
+	if familyNode == nil {
+		t.Fatal("family node not found in subtree")
+		return
+	}
+
+	reachable := reachableNodes(t, subGraph, familyNode)
+	for _, node := range subNodes {
+		name, _ := node.Name()
+		if _, ok := reachable[name]; !ok {
+			t.Fatalf("node %q is not reachable from family 7MX", node.Label())
+			return
+		}
+	}
+}
+
+func TestBuildGraphUnknownFamily(t *testing.T) {
+	ctx := context.Background()
+	reg, err := referencedata.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err := buildGraph(ctx, g, reg, graphOptions{family: "does-not-exist"}); err == nil {
+		t.Fatal("expected error for unknown family")
+		return
+	}
+}
+
+func TestDotSourceIsDeterministic(t *testing.T) {
+	reg, err := referencedata.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	first, err := dotSource(reg)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	second, err := dotSource(reg)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if first != second {
+		t.Fatal("expected the DOT source of the same registry to be identical")
+		return
+	}
+}
+
+func TestDotSourceIgnoresFileOrder(t *testing.T) {
+	var sources []string
+	for _, rows := range [][3]string{
+		{"320,32S,320,A320,Airbus A320\n738,,738,B738,Boeing 737-800\n", "32S,32S,,,family,Airbus A320\n", "32A,320,\n73H,738,\n"},
+		{"738,,738,B738,Boeing 737-800\n320,32S,320,A320,Airbus A320\n", "32S,32S,,,family,Airbus A320\n", "73H,738,\n32A,320,\n"},
+	} {
+		reg, err := referencedata.NewRegistryFromReaders(
+			strings.NewReader("id,family_id,iata,icao,name\n"+rows[0]),
+			strings.NewReader("id,iata,icao,parent_family,level,name\n"+rows[1]),
+			strings.NewReader("alias,aircraft_type,aircraft_family\n"+rows[2]),
+		)
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		source, err := dotSource(reg)
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		sources = append(sources, source)
+	}
+
+	if sources[0] != sources[1] {
+		t.Fatalf("expected the DOT source to be independent of the file order, got\n%s\nand\n%s", sources[0], sources[1])
+		return
+	}
+}
+
+func TestRenderToBytes(t *testing.T) {
+	reg, err := referencedata.NewRegistryFromReaders(
+		strings.NewReader("id,family_id,iata,icao,name\n320,32S,320,A320,Airbus A320\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,,family,Airbus A320\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	b, err := renderToBytes(context.Background(), reg, defaultGraphStyle, graphviz.SVG)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if !bytes.Contains(b, []byte("<svg")) {
+		t.Fatalf("expected svg output, got %q", b)
+		return
+	}
+}
+
+func TestBuildGraphDepth(t *testing.T) {
+	ctx := context.Background()
+	reg, err := referencedata.NewRegistryFromReaders(
+		strings.NewReader("id,family_id,iata,icao,name\n320,32S,320,A320,Airbus A320\n388,AIRBUS,388,A388,Airbus A380-800\n738,BOEING,738,B738,Boeing 737-800\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,AIRBUS,family,Airbus A320\nAIRBUS,,,,manufacturer,Airbus\nBOEING,,,,manufacturer,Boeing\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32A,320,\n32C,,32S\n38A,388,\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	graph, err := buildGraph(ctx, g, reg, graphOptions{depth: 1})
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var labels []string
+	for _, node := range graphNodes(t, graph) {
+		labels = append(labels, strings.SplitN(node.Label(), "\n", 3)[1])
+	}
+
+	slices.Sort(labels)
+	if expected := []string{"Airbus", "Airbus A380-800", "Boeing", "Boeing 737-800", "IATA: 38A"}; !slices.Equal(labels, expected) {
+		t.Fatalf("expected only root families, their aircraft types and aliases %v, got %v", expected, labels)
+		return
+	}
+}
+
+func TestBuildGraphModes(t *testing.T) {
+	ctx := context.Background()
+	reg, err := referencedata.NewRegistryFromReaders(
+		strings.NewReader("id,family_id,iata,icao,name\n320,32S,320,A320,Airbus A320\n738,,738,B738,Boeing 737-800\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,,family,Airbus A320\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32C,,32S\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, tc := range []struct {
+		mode     graphMode
+		expected []string
+	}{
+		{graphModeFull, []string{"Airbus A320", "Airbus A320", "Boeing 737-800", "IATA: 32C"}},
+		{graphModeFamilies, []string{"Airbus A320", "Airbus A320", "Boeing 737-800"}},
+		{graphModeAliases, []string{"Airbus A320", "IATA: 32C"}},
+	} {
+		graph, err := buildGraph(ctx, g, reg, graphOptions{mode: tc.mode})
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		var labels []string
+		for _, node := range graphNodes(t, graph) {
+			labels = append(labels, strings.SplitN(node.Label(), "\n", 3)[1])
+		}
+
+		slices.Sort(labels)
+		if !slices.Equal(labels, tc.expected) {
+			t.Fatalf("expected labels %v in mode %s, got %v", tc.expected, tc.mode, labels)
+			return
+		}
+	}
+}
+
+func TestBuildGraphLabelTemplate(t *testing.T) {
+	ctx := context.Background()
+	reg, err := referencedata.NewRegistryFromReaders(
+		strings.NewReader("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n738,,738,B738,Boeing 737-800\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, tc := range []struct {
+		template string
+		expected []string
+	}{
+		{defaultLabelTemplate, []string{"Aircraft\nAirbus A320\nIATA: 320\nICAO: A320", "Aircraft\nBoeing 737-800\nIATA: 738\nICAO: B738"}},
+		{"{{.IATA}}", []string{"320", "738"}},
+	} {
+		labelTemplate, err := parseLabelTemplate(tc.template)
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		graph, err := buildGraph(ctx, g, reg, graphOptions{labelTemplate: labelTemplate})
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		var labels []string
+		for _, node := range graphNodes(t, graph) {
+			labels = append(labels, node.Label())
+		}
+
+		if !slices.Equal(labels, tc.expected) {
+			t.Fatalf("expected labels %q for template %q, got %q", tc.expected, tc.template, labels)
+			return
+		}
+	}
+
+	for _, invalid := range []string{"{{.IATA", "{{.DoesNotExist}}"} {
+		if _, err := parseLabelTemplate(invalid); err == nil {
+			t.Fatalf("expected template %q to be rejected", invalid)
+			return
+		}
+	}
+}
+
+func TestBuildGraphBodyTypeColors(t *testing.T) {
+	ctx := context.Background()
+	reg, err := referencedata.NewRegistryFromReaders(
+		strings.NewReader("id,family_id,iata,icao,body_type,name\n320,,320,A320,narrowbody,Airbus A320\n359,,359,A359,widebody,Airbus A350-900\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	graph, err := buildGraph(ctx, g, reg, graphOptions{})
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	nodes := graphNodes(t, graph)
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(nodes))
+		return
+	}
+
+	if a, b := nodes[0].GetStr("fillcolor"), nodes[1].GetStr("fillcolor"); a == "" || a == b {
+		t.Fatalf("expected distinct fill colors, got %q and %q", a, b)
+		return
+	}
+}
+
+func TestBuildGraphWithStyle(t *testing.T) {
+	ctx := context.Background()
+	reg, err := referencedata.NewRegistryFromReaders(
+		strings.NewReader("id,family_id,iata,icao,name\n320,32S,320,A320,Airbus A320\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,,family,Airbus A320\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	defaultGraph, err := buildGraph(ctx, g, reg, graphOptions{})
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	styledGraph, err := buildGraphWithStyle(ctx, g, reg, graphOptions{}, GraphStyle{RankDir: graphviz.TBRank, FamilyNodeColor: "#cccccc", FamilyNodeShape: graphviz.BoxShape})
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if a, b := defaultGraph.GetStr("rankdir"), styledGraph.GetStr("rankdir"); a != string(graphviz.LRRank) || b != string(graphviz.TBRank) {
+		t.Fatalf("expected rank directions LR and TB, got %q and %q", a, b)
+		return
+	}
+
+	for _, node := range graphNodes(t, styledGraph) {
+		isFamily := strings.HasPrefix(node.Label(), "Family\n")
+		if isFamily && (node.GetStr("fillcolor") != "#cccccc" || node.GetStr("shape") != string(graphviz.BoxShape)) {
+			t.Fatalf("expected the family style, got fill color %q and shape %q", node.GetStr("fillcolor"), node.GetStr("shape"))
+			return
+		} else if !isFamily && node.GetStr("fillcolor") != colorForBodyType("") {
+			t.Fatalf("expected the body type color for aircraft types, got %q", node.GetStr("fillcolor"))
+			return
+		}
+	}
+}
+
+func TestBuildGraphCommercialName(t *testing.T) {
+	ctx := context.Background()
+	reg, err := referencedata.NewRegistryFromReaders(
+		strings.NewReader("id,family_id,iata,icao,name\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,commercial_name,name\n32S,32S,,,family,A320 Family,Airbus A320 Family\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	graph, err := buildGraph(ctx, g, reg, graphOptions{})
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	nodes := graphNodes(t, graph)
+	if len(nodes) != 1 || nodes[0].Label() != "Family\nA320 Family\nIATA: 32S" {
+		t.Fatalf("expected the family node to be labeled with the commercial name, got %v", nodes)
+		return
+	}
+}
+
+func TestBuildGraphManufacturerClusters(t *testing.T) {
+	ctx := context.Background()
+	reg, err := referencedata.NewRegistryFromReaders(
+		strings.NewReader("id,family_id,iata,icao,body_type,name\n320,32S,320,A320,narrowbody,Airbus A320\n359,AIRBUS,359,A359,widebody,Airbus A350-900\n738,BOEING,738,B738,narrowbody,Boeing 737-800\nF70,,F70,F70,regional_jet,Fokker 70\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,AIRBUS,family,Airbus A320\nAIRBUS,,,,manufacturer,Airbus\nBOEING,,,,manufacturer,Boeing\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	graph, err := buildGraph(ctx, g, reg, graphOptions{})
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	subGraphs, err := graph.SubGraphNum()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if subGraphs != 2 {
+		t.Fatalf("expected 2 manufacturer clusters, got %d", subGraphs)
+		return
+	}
+}
+
+func TestBuildGraphVariants(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"aircraft_types.csv":    "id,family_id,iata,icao,body_type,name\n32Q,,32Q,A21N,narrowbody,Airbus A321neo\n",
+		"aircraft_families.csv": "id,iata,icao,parent_family,level,name\n",
+		"aircraft_aliases.csv":  "alias,aircraft_type,aircraft_family\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+			return
+		}
+	}
+
+	// the variants of 32Q are loaded from the embedded aircraft_variants.csv
+	reg, err := referencedata.NewRegistryFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	graph, err := buildGraph(ctx, g, reg, graphOptions{})
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	nodes := graphNodes(t, graph)
+	if expected := 1 + len(reg.TypeVariants("32Q")); len(nodes) != expected || expected < 2 {
+		t.Fatalf("expected %d nodes, got %d", expected, len(nodes))
+		return
+	}
+
+	typeNode, variantNode := nodes[0], nodes[1]
+	if variantNode.GetStr("shape") == typeNode.GetStr("shape") {
+		t.Fatalf("expected variant node to have a distinct shape, both are %q", variantNode.GetStr("shape"))
+		return
+	}
+
+	edge, err := graph.FirstOut(typeNode)
+	if err != nil {
+		t.Fatal(err)
+		return
+	} else if edge == nil {
+		t.Fatal("expected an edge from the aircraft type to its variant")
+		return
+	}
+
+	if head, err := edge.Head(); err != nil || head.Label() != variantNode.Label() {
+		t.Fatalf("expected edge to point to the variant node, got %v", err)
+		return
+	}
+}
+
+func TestBuildGraphDanglingAlias(t *testing.T) {
+	ctx := context.Background()
+	reg, err := referencedata.NewRegistryFromReaders(
+		strings.NewReader("id,family_id,iata,icao,body_type,name\n320,,320,A320,narrowbody,Airbus A320\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32A,320,\n32X,XXX,\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	graph, err := buildGraph(ctx, g, reg, graphOptions{})
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if !strings.Contains(buf.String(), `warning: missing node for id "XXX" at alias "32X"`) {
+		t.Fatalf("expected warning for dangling alias, got %q", buf.String())
+		return
+	}
+
+	if nodes := graphNodes(t, graph); len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(nodes))
+		return
+	}
+}
+
+func TestRenderTimeout(t *testing.T) {
+	err := render(context.Background(), blockingRenderer{}, nil, graphviz.SVG, io.Discard, 10*time.Millisecond)
+	if !errors.Is(err, errRenderTimeout) {
+		t.Fatalf("expected errRenderTimeout, got %v", err)
+		return
+	}
+}
+
+// lateRenderer writes to w after the context is done, like a renderer which only checks the context between steps.
+type lateRenderer struct{}
+
+func (lateRenderer) Render(ctx context.Context, _ *graphviz.Graph, _ graphviz.Format, w io.Writer) error {
+	<-ctx.Done()
+	time.Sleep(10 * time.Millisecond)
+	_, err := io.WriteString(w, "late")
+	return err
+}
+
+func TestRenderTimeoutWaitsForRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	err := render(context.Background(), lateRenderer{}, nil, graphviz.SVG, &buf, 10*time.Millisecond)
+	if !errors.Is(err, errRenderTimeout) {
+		t.Fatalf("expected errRenderTimeout, got %v", err)
+		return
+	}
+
+	if buf.String() != "late" {
+		t.Fatalf("expected render to return after the renderer finished writing, got %q", buf.String())
+		return
+	}
+}
+
+func TestRenderCancelledWithTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := render(ctx, blockingRenderer{}, nil, graphviz.SVG, io.Discard, time.Minute)
+	if !errors.Is(err, context.Canceled) || errors.Is(err, errRenderTimeout) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+		return
+	}
+}
+
+func graphNodes(t *testing.T, graph *graphviz.Graph) []*graphviz.Node {
+	var nodes []*graphviz.Node
+	node, err := graph.FirstNode()
+	for node != nil && err == nil {
+		nodes = append(nodes, node)
+		node, err = graph.NextNode(node)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return nodes
+}
+
+// reachableNodes returns the names of all nodes connected to start, ignoring edge direction.
+func reachableNodes(t *testing.T, graph *graphviz.Graph, start *graphviz.Node) map[string]struct{} {
+	startName, _ := start.Name()
+	visited := map[string]struct{}{startName: {}}
+	queue := []*graphviz.Node{start}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		edge, err := graph.FirstEdge(node)
+		for edge != nil && err == nil {
+			for _, endpoint := range []func() (*graphviz.Node, error){edge.Head, edge.Tail} {
+				other, err := endpoint()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				name, _ := other.Name()
+				if _, ok := visited[name]; !ok {
+					visited[name] = struct{}{}
+					queue = append(queue, other)
+				}
+			}
+
+			edge, err = graph.NextEdge(edge, node)
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return visited
+}
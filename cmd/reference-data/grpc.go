@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"github.com/explore-flights/reference-data/referencedatapb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcServer implements referencedatapb.ReferenceDataServiceServer backed by a Registry.
+type grpcServer struct {
+	referencedatapb.UnimplementedReferenceDataServiceServer
+	reg *referencedata.Registry
+}
+
+// newGRPCServer returns a gRPC server with the reference data service of reg registered.
+func newGRPCServer(reg *referencedata.Registry) *grpc.Server {
+	srv := grpc.NewServer()
+	referencedatapb.RegisterReferenceDataServiceServer(srv, &grpcServer{reg: reg})
+	return srv
+}
+
+func (s *grpcServer) LookupByIATA(_ context.Context, req *referencedatapb.LookupByIATARequest) (*referencedatapb.LookupByIATAResponse, error) {
+	res, err := s.reg.LookupByIATA(referencedata.IATA(req.GetIata()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	if res.Type != nil {
+		return &referencedatapb.LookupByIATAResponse{Result: &referencedatapb.LookupByIATAResponse_Type{Type: aircraftTypeToProto(res.Type)}}, nil
+	}
+
+	return &referencedatapb.LookupByIATAResponse{Result: &referencedatapb.LookupByIATAResponse_Family{Family: aircraftFamilyToProto(res.Family)}}, nil
+}
+
+func (s *grpcServer) LookupByICAO(_ context.Context, req *referencedatapb.LookupByICAORequest) (*referencedatapb.LookupByICAOResponse, error) {
+	aircraftTypes, f, err := s.reg.LookupByICAO(referencedata.ICAO(req.GetIcao()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &referencedatapb.LookupByICAOResponse{
+		Types:  aircraftTypesToProto(aircraftTypes),
+		Family: aircraftFamilyToProto(f),
+	}, nil
+}
+
+func (s *grpcServer) ListFamily(_ context.Context, req *referencedatapb.ListFamilyRequest) (*referencedatapb.ListFamilyResponse, error) {
+	f, ok := s.reg.Family(req.GetId())
+	if !ok {
+		return nil, grpcError(referencedata.ErrNotFound)
+	}
+
+	subFamilies, aircraftTypes, err := s.reg.FamilyChildren(f.Id)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &referencedatapb.ListFamilyResponse{
+		Family:      aircraftFamilyToProto(f),
+		SubFamilies: aircraftFamiliesToProto(subFamilies),
+		Types:       aircraftTypesToProto(aircraftTypes),
+	}, nil
+}
+
+func (s *grpcServer) ListDescendants(_ context.Context, req *referencedatapb.ListDescendantsRequest) (*referencedatapb.ListDescendantsResponse, error) {
+	descendants, aircraftTypes, err := s.reg.FamilyDescendants(req.GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &referencedatapb.ListDescendantsResponse{
+		Families: aircraftFamiliesToProto(descendants),
+		Types:    aircraftTypesToProto(aircraftTypes),
+	}, nil
+}
+
+func grpcError(err error) error {
+	if errors.Is(err, referencedata.ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+func aircraftTypeToProto(t *referencedata.AircraftType) *referencedatapb.AircraftType {
+	return &referencedatapb.AircraftType{
+		Id:          t.Id,
+		FamilyId:    t.FamilyId,
+		Iata:        string(t.IATA),
+		Icao:        string(t.ICAO),
+		Wtc:         t.WTC,
+		EngineCount: int32(t.EngineCount),
+		EngineType:  string(t.EngineType),
+		BodyType:    string(t.BodyType),
+		Name:        t.Name,
+	}
+}
+
+func aircraftTypesToProto(aircraftTypes []*referencedata.AircraftType) []*referencedatapb.AircraftType {
+	res := make([]*referencedatapb.AircraftType, 0, len(aircraftTypes))
+	for _, t := range aircraftTypes {
+		res = append(res, aircraftTypeToProto(t))
+	}
+
+	return res
+}
+
+func aircraftFamilyToProto(f *referencedata.AircraftFamily) *referencedatapb.AircraftFamily {
+	if f == nil {
+		return nil
+	}
+
+	return &referencedatapb.AircraftFamily{
+		Id:             f.Id,
+		Iata:           string(f.IATA),
+		Icao:           string(f.ICAO),
+		ParentFamilyId: f.ParentFamilyId,
+		Level:          f.Level,
+		Name:           f.Name,
+	}
+}
+
+func aircraftFamiliesToProto(aircraftFamilies []*referencedata.AircraftFamily) []*referencedatapb.AircraftFamily {
+	res := make([]*referencedatapb.AircraftFamily, 0, len(aircraftFamilies))
+	for _, f := range aircraftFamilies {
+		res = append(res, aircraftFamilyToProto(f))
+	}
+
+	return res
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"github.com/explore-flights/reference-data/referencedatapb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"testing"
+)
+
+func TestGRPCServer(t *testing.T) {
+	reg, err := referencedata.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	srv := newGRPCServer(reg)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	client := referencedatapb.NewReferenceDataServiceClient(conn)
+	ctx := context.Background()
+
+	res, err := client.LookupByIATA(ctx, &referencedatapb.LookupByIATARequest{Iata: "20N"})
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if res.GetType().GetId() != "32N" {
+		t.Fatalf("expected aircraft type 32N, got %v", res)
+		return
+	}
+
+	descendants, err := client.ListDescendants(ctx, &referencedatapb.ListDescendantsRequest{Id: "BOEING"})
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(descendants.GetFamilies()) == 0 || len(descendants.GetTypes()) == 0 {
+		t.Fatalf("expected descendants of BOEING, got %v", descendants)
+		return
+	}
+
+	if _, err = client.LookupByIATA(ctx, &referencedatapb.LookupByIATARequest{Iata: "ZZZ"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+		return
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/explore-flights/reference-data/internal/atomicfile"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"github.com/explore-flights/reference-data/pkg/referencedata/cmd"
+	"github.com/goccy/go-graphviz"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+// outputFormats maps the values of the format flag to the graphviz render formats.
+var outputFormats = map[string]graphviz.Format{
+	"svg": graphviz.SVG,
+	"png": graphviz.PNG,
+	"jpg": graphviz.JPG,
+	"dot": graphviz.XDOT,
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		if errors.Is(err, errRenderTimeout) {
+			log.Print(err)
+			os.Exit(2)
+			return
+		}
+
+		log.Fatal(err)
+		return
+	}
+}
+
+// commands maps subcommand names to their implementation. Without a subcommand the graph is rendered.
+var commands = map[string]func(ctx context.Context, args []string) error{
+	"diff":        runDiff,
+	"html-report": runHTMLReport,
+	"openapi":     runOpenAPI,
+	"serve":       runServe,
+	"snapshot":    runSnapshot,
+	"validate":    runValidate,
+}
+
+// watchDefaultDir is the directory of the csv files watched by the watch flag unless their paths are given.
+// It is relative to the root of the repository, where the embedded files live.
+var watchDefaultDir = filepath.Join("pkg", "referencedata")
+
+// graphOutput is a file the graph is rendered to.
+type graphOutput struct {
+	path   string
+	format graphviz.Format
+	mode   graphMode
+}
+
+// parseMode parses a value of the mode flag.
+func parseMode(value string) (graphMode, error) {
+	if mode := graphMode(value); slices.Contains(graphModes, mode) {
+		return mode, nil
+	}
+
+	return "", fmt.Errorf("unsupported mode %q", value)
+}
+
+// parseExtraOutput parses a single value of the extra-output flag, which is rendered in defaultMode unless
+// the value is prefixed with a mode.
+func parseExtraOutput(value string, defaultMode graphMode) (graphOutput, error) {
+	o := graphOutput{path: value, mode: defaultMode}
+	if mode, path, ok := strings.Cut(value, "="); ok {
+		o.path, o.mode = path, graphMode(mode)
+		if !slices.Contains(graphModes, o.mode) {
+			return graphOutput{}, fmt.Errorf("unsupported mode %q of extra output %q", mode, value)
+		}
+	}
+
+	format, ok := outputFormats[strings.TrimPrefix(filepath.Ext(o.path), ".")]
+	if !ok {
+		return graphOutput{}, fmt.Errorf("unsupported format of extra output %q", value)
+	}
+
+	o.format = format
+	return o, nil
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd(ctx, args[1:])
+		}
+	}
+
+	return runGraph(ctx, args)
+}
+
+func runGraph(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("reference-data", flag.ContinueOnError)
+	common := cmd.CommonFlags{Format: "svg"}
+	common.Register(flags, cmd.Usage{
+		Output: "output file path (default graph.<format>)",
+		Format: "output format, one of svg, png, jpg or dot",
+	})
+	timeout := flags.Duration("timeout", 0, "maximum duration for rendering the graph, zero means no timeout")
+	typesPath := flags.String("types", "", "path to aircraft_types.csv (default embedded)")
+	familiesPath := flags.String("families", "", "path to aircraft_families.csv (default embedded)")
+	aliasesPath := flags.String("aliases", "", "path to aircraft_aliases.csv (default embedded)")
+	labelTemplateText := flags.String("label-template", defaultLabelTemplate, "text/template for the labels of aircraft type nodes, executed with the aircraft type")
+	watch := flags.Bool("watch", false, "re-render the graph whenever one of the csv files changes; paths default to the files in pkg/referencedata below the working directory")
+	var extraOutputs []graphOutput
+	extraOutputMode := graphModeFull
+	flags.Func("mode", "`mode` of the extra outputs following this flag, one of full, families or aliases (default full)", func(value string) error {
+		mode, err := parseMode(value)
+		if err != nil {
+			return err
+		}
+
+		extraOutputMode = mode
+		return nil
+	})
+	flags.Func("extra-output", "comma separated `[mode=]path` list of additional outputs, repeatable; the format is inferred from the file extension, a mode= prefix overrides the mode flag for this path", func(value string) error {
+		for _, v := range strings.Split(value, ",") {
+			o, err := parseExtraOutput(v, extraOutputMode)
+			if err != nil {
+				return err
+			}
+
+			extraOutputs = append(extraOutputs, o)
+		}
+
+		return nil
+	})
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if common.Output == "" {
+		common.Output = "graph." + common.Format
+	}
+
+	outputFormat, ok := outputFormats[common.Format]
+	if !ok {
+		return fmt.Errorf("unsupported format %q", common.Format)
+	}
+
+	outputs := append([]graphOutput{{path: common.Output, format: outputFormat, mode: graphModeFull}}, extraOutputs...)
+	outputPaths := make([]string, len(outputs))
+	for i, o := range outputs {
+		outputPaths[i] = o.path
+	}
+
+	labelTemplate, err := parseLabelTemplate(*labelTemplateText)
+	if err != nil {
+		return err
+	}
+
+	if *watch {
+		for _, path := range []struct {
+			value       *string
+			defaultPath string
+		}{
+			{typesPath, filepath.Join(watchDefaultDir, "aircraft_types.csv")},
+			{familiesPath, filepath.Join(watchDefaultDir, "aircraft_families.csv")},
+			{aliasesPath, filepath.Join(watchDefaultDir, "aircraft_aliases.csv")},
+		} {
+			if *path.value == "" {
+				*path.value = path.defaultPath
+			}
+		}
+	}
+
+	g, err := graphviz.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer g.Close()
+
+	renderGraph := func() error {
+		reg, err := referencedata.NewRegistryFromPaths(*typesPath, *familiesPath, *aliasesPath)
+		if err != nil {
+			return err
+		}
+
+		for _, o := range outputs {
+			graph, err := buildGraph(ctx, g, reg, graphOptions{family: common.Family, depth: common.Depth, labelTemplate: labelTemplate, mode: o.mode})
+			if err != nil {
+				return err
+			}
+
+			err = atomicfile.Write(o.path, func(w io.Writer) error {
+				return render(ctx, g, graph, o.format, w, *timeout)
+			})
+			graph.Close()
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", o.path, err)
+			}
+		}
+
+		return nil
+	}
+
+	if err := renderGraph(); err != nil {
+		return err
+	}
+
+	if !*watch {
+		return nil
+	}
+
+	return watchFiles(ctx, []string{*typesPath, *familiesPath, *aliasesPath}, nil, func() {
+		if err := renderGraph(); err != nil {
+			log.Printf("failed to re-render %s: %v", strings.Join(outputPaths, ", "), err)
+			return
+		}
+
+		log.Printf("re-rendered %s", strings.Join(outputPaths, ", "))
+	})
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMainGraphOutputFileCreated(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	if err := run(context.Background(), nil); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "graph.svg"))
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(b) == 0 {
+		t.Fatal("graph.svg is empty")
+		return
+	}
+
+	if !bytes.HasPrefix(b, []byte("<?xml")) {
+		t.Fatalf("graph.svg does not start with an xml header: %q", b[:min(len(b), 32)])
+		return
+	}
+}
+
+func TestMainGraphOutputFormatPNG(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	if err := run(context.Background(), []string{"--format=png"}); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "graph.png"))
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if !bytes.HasPrefix(b, []byte("\x89PNG")) {
+		t.Fatalf("graph.png does not start with the png magic bytes: %q", b[:min(len(b), 8)])
+		return
+	}
+}
+
+func TestMainGraphOutputPath(t *testing.T) {
+	for _, flagName := range []string{"-o", "--output"} {
+		output := filepath.Join(t.TempDir(), "custom.svg")
+		if err := run(context.Background(), []string{flagName, output}); err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		if _, err := os.Stat(output); err != nil {
+			t.Fatalf("expected output at %s with %s: %v", output, flagName, err)
+			return
+		}
+	}
+}
+
+func TestMainGraphExtraOutputMode(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "graph.dot")
+	families := filepath.Join(dir, "families.dot")
+	aliases := filepath.Join(dir, "aliases.dot")
+	if err := run(context.Background(), []string{"-o", output, "--format", "dot", "--mode", "families", "--extra-output", families, "--mode=aliases", "--extra-output", aliases}); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var sizes []int
+	for _, path := range []string{output, families, aliases} {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		sizes = append(sizes, len(b))
+	}
+
+	// the subgraphs of the modes leave out parts of the full graph
+	if sizes[1] >= sizes[0] || sizes[2] >= sizes[0] || sizes[1] == sizes[2] {
+		t.Fatalf("expected the extra outputs to be rendered in their modes, got sizes %v", sizes)
+		return
+	}
+
+	if err := run(context.Background(), []string{"-o", output, "--mode", "unknown", "--extra-output", families}); err == nil {
+		t.Fatal("expected unknown mode to be rejected")
+		return
+	}
+}
+
+func TestMainGraphExtraOutput(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "graph.svg")
+	families := filepath.Join(dir, "families.svg")
+	aliases := filepath.Join(dir, "aliases.dot")
+	if err := run(context.Background(), []string{"-o", output, "--extra-output", "families=" + families + ",aliases=" + aliases}); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, path := range []string{output, families, aliases} {
+		if fi, err := os.Stat(path); err != nil {
+			t.Fatal(err)
+			return
+		} else if fi.Size() == 0 {
+			t.Fatalf("%s is empty", path)
+			return
+		}
+	}
+
+	for _, invalid := range []string{"graph.pdf", "unknown=graph.svg"} {
+		if err := run(context.Background(), []string{"-o", output, "--extra-output", filepath.Join(dir, invalid)}); err == nil {
+			t.Fatalf("expected extra output %q to be rejected", invalid)
+			return
+		}
+	}
+}
+
+func TestMainGraphOutputFormatUnsupported(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if err := run(context.Background(), []string{"--format=pdf"}); err == nil {
+		t.Fatal("expected error for unsupported format")
+		return
+	}
+}
+
+func TestMainValidate(t *testing.T) {
+	if err := run(context.Background(), []string{"validate"}); err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestMainValidateWarnings(t *testing.T) {
+	if err := run(context.Background(), []string{"validate", "--warnings"}); err != nil {
+		t.Fatal(err)
+		return
+	}
+}
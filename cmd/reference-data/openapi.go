@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// openAPIExamples are the codes used as examples in the spec. They resolve in the embedded data.
+var openAPIExamples = struct {
+	alias    referencedata.IATA
+	familyId string
+	typeCode referencedata.IATA
+}{"20N", "737", "32N"}
+
+// openAPIEnums lists the allowed values of string types which are rendered as enums.
+var openAPIEnums = map[reflect.Type][]string{
+	reflect.TypeFor[referencedata.BodyType]():   enumValues(referencedata.BodyTypes()),
+	reflect.TypeFor[referencedata.EngineType](): enumValues(referencedata.EngineTypes()),
+}
+
+// OpenAPI3Spec returns a JSON encoded OpenAPI 3.0 document describing the endpoints of the serve subcommand.
+// Response schemas are derived from the types returned by the handlers.
+func OpenAPI3Spec() []byte {
+	schemas := make(map[string]any)
+	lookupResult := openAPISchema(reflect.TypeFor[referencedata.LookupResult](), schemas)
+	family := openAPISchema(reflect.TypeFor[referencedata.AircraftFamily](), schemas)
+	aliases := openAPISchema(reflect.TypeFor[[]referencedata.IATA](), schemas)
+	errorResponse := map[string]any{
+		"description": "Error",
+		"content": map[string]any{
+			"application/json": map[string]any{
+				"schema": map[string]any{"$ref": "#/components/schemas/Error"},
+			},
+		},
+	}
+	schemas["Error"] = map[string]any{
+		"type":       "object",
+		"required":   []string{"error"},
+		"properties": map[string]any{"error": map[string]any{"type": "string"}},
+	}
+
+	var lookupExample, familyExample, aliasesExample any
+	if reg, err := referencedata.NewRegistry(); err == nil {
+		lookupExample, _ = reg.LookupByIATA(openAPIExamples.alias)
+		familyExample, _ = reg.Family(openAPIExamples.familyId)
+		aliasesExample, _ = reg.AllAliasesFor(openAPIExamples.typeCode)
+	}
+
+	spec := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "reference-data",
+			"version": "1.0.0",
+		},
+		"paths": map[string]any{
+			"/aircraft": map[string]any{
+				"get": map[string]any{
+					"operationId": "lookupAircraft",
+					"summary":     "Resolves an IATA code to an aircraft type or family, following aliases",
+					"parameters": []any{
+						openAPIParameter("iata", "query", openAPIExamples.alias),
+					},
+					"responses": map[string]any{
+						"200": openAPIResponse(lookupResult, lookupExample),
+						"400": errorResponse,
+						"404": errorResponse,
+					},
+				},
+			},
+			"/families/{id}": map[string]any{
+				"get": map[string]any{
+					"operationId": "getFamily",
+					"summary":     "Returns the aircraft family with the given id",
+					"parameters": []any{
+						openAPIParameter("id", "path", openAPIExamples.familyId),
+					},
+					"responses": map[string]any{
+						"200": openAPIResponse(family, familyExample),
+						"404": errorResponse,
+					},
+				},
+			},
+			"/aliases/{iata}": map[string]any{
+				"get": map[string]any{
+					"operationId": "listAliases",
+					"summary":     "Returns all IATA codes resolving to the same target as the given code, including the code itself",
+					"parameters": []any{
+						openAPIParameter("iata", "path", openAPIExamples.typeCode),
+					},
+					"responses": map[string]any{
+						"200": openAPIResponse(aliases, aliasesExample),
+						"404": errorResponse,
+					},
+				},
+			},
+		},
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}
+
+	b, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		// the spec only consists of maps, slices and registry types, all of which can be encoded
+		panic(err)
+	}
+
+	return b
+}
+
+func openAPIParameter(name, in string, example any) map[string]any {
+	return map[string]any{
+		"name":     name,
+		"in":       in,
+		"required": true,
+		"schema":   map[string]any{"type": "string"},
+		"example":  example,
+	}
+}
+
+func openAPIResponse(schema map[string]any, example any) map[string]any {
+	content := map[string]any{"schema": schema}
+	if example != nil {
+		content["example"] = example
+	}
+
+	return map[string]any{
+		"description": "OK",
+		"content": map[string]any{
+			"application/json": content,
+		},
+	}
+}
+
+// openAPISchema returns the schema of t. Structs are added to schemas and referenced by name.
+func openAPISchema(t reflect.Type, schemas map[string]any) map[string]any {
+	if values, ok := openAPIEnums[t]; ok {
+		return map[string]any{"type": "string", "enum": values}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return openAPISchema(t.Elem(), schemas)
+
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": openAPISchema(t.Elem(), schemas)}
+
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+
+	case reflect.Int:
+		return map[string]any{"type": "integer"}
+
+	case reflect.Struct:
+		if _, ok := schemas[t.Name()]; !ok {
+			// reserve the name first so recursive types terminate
+			schemas[t.Name()] = nil
+
+			properties := make(map[string]any)
+			var required []string
+			for _, field := range reflect.VisibleFields(t) {
+				name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+				if name == "" || name == "-" {
+					continue
+				}
+
+				properties[name] = openAPISchema(field.Type, schemas)
+				if !strings.Contains(opts, "omitempty") {
+					required = append(required, name)
+				}
+			}
+
+			schema := map[string]any{"type": "object", "properties": properties}
+			if len(required) > 0 {
+				schema["required"] = required
+			}
+
+			schemas[t.Name()] = schema
+		}
+
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+func enumValues[T ~string](values []T) []string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+
+	return s
+}
+
+// runOpenAPI implements the openapi subcommand, which writes the OpenAPI spec of the serve subcommand to stdout.
+func runOpenAPI(_ context.Context, args []string) error {
+	flags := flag.NewFlagSet("reference-data openapi", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	_, err := os.Stdout.Write(append(OpenAPI3Spec(), '\n'))
+	return err
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOpenAPI3Spec(t *testing.T) {
+	var spec map[string]interface{}
+	if err := json.Unmarshal(OpenAPI3Spec(), &spec); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	paths, ok := spec["paths"].(map[string]interface{})
+	if !ok || len(paths) == 0 {
+		t.Fatalf("expected non-empty paths, got %v", spec["paths"])
+		return
+	}
+
+	for _, path := range []string{"/aircraft", "/families/{id}", "/aliases/{iata}"} {
+		op, ok := paths[path].(map[string]interface{})["get"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected a GET operation for %s", path)
+			return
+		}
+
+		content := op["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})
+		if content["example"] == nil {
+			t.Fatalf("expected an example response for %s", path)
+			return
+		}
+	}
+
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	for _, name := range []string{"LookupResult", "AircraftType", "AircraftFamily", "Error"} {
+		if _, ok := schemas[name]; !ok {
+			t.Fatalf("expected schema %s", name)
+			return
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"flag"
+	"github.com/explore-flights/reference-data/internal/atomicfile"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"github.com/explore-flights/reference-data/pkg/referencedata/cmd"
+	"html/template"
+	"io"
+	"strings"
+)
+
+//go:embed report.html.tmpl
+var reportTemplateText string
+
+var reportTemplate = template.Must(template.New("report").Parse(reportTemplateText))
+
+type reportData struct {
+	Roots []*reportFamily
+	Rows  []reportRow
+}
+
+// reportFamily is a node of the family tree in the report sidebar.
+type reportFamily struct {
+	Family   *referencedata.AircraftFamily
+	Types    []*referencedata.AircraftType
+	Children []*reportFamily
+	// Ids are the space separated ids of the family and all its descendants, used to filter the table.
+	Ids string
+}
+
+type reportRow struct {
+	Type       *referencedata.AircraftType
+	FamilyId   string
+	FamilyName string
+}
+
+// runHTMLReport implements the html-report subcommand, which writes a self-contained HTML page
+// with a searchable table of all aircraft types and the family tree.
+func runHTMLReport(_ context.Context, args []string) error {
+	flags := flag.NewFlagSet("reference-data html-report", flag.ContinueOnError)
+	common := cmd.CommonFlags{Output: "report.html"}
+	common.RegisterOutput(flags, "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	reg, err := referencedata.NewRegistry()
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.Write(common.Output, func(w io.Writer) error {
+		return writeHTMLReport(w, reg)
+	})
+}
+
+// writeHTMLReport writes the report of reg to w. The page has no external dependencies.
+func writeHTMLReport(w io.Writer, reg *referencedata.Registry) error {
+	var data reportData
+	for _, t := range reg.Types() {
+		row := reportRow{Type: t}
+		if f, ok := reg.Family(t.FamilyId); ok {
+			row.FamilyId, row.FamilyName = f.Id, f.Name
+		}
+
+		data.Rows = append(data.Rows, row)
+	}
+
+	visited := make(map[string]struct{})
+	for _, f := range reg.Families() {
+		if _, ok := reg.Family(f.ParentFamilyId); !ok {
+			data.Roots = append(data.Roots, reportFamilyTree(reg, f, visited))
+		}
+	}
+
+	return reportTemplate.Execute(w, data)
+}
+
+func reportFamilyTree(reg *referencedata.Registry, f *referencedata.AircraftFamily, visited map[string]struct{}) *reportFamily {
+	visited[f.Id] = struct{}{}
+	node := &reportFamily{Family: f}
+	ids := []string{f.Id}
+
+	children, aircraftTypes, _ := reg.FamilyChildren(f.Id)
+	node.Types = aircraftTypes
+	for _, child := range children {
+		if _, ok := visited[child.Id]; ok {
+			continue
+		}
+
+		childNode := reportFamilyTree(reg, child, visited)
+		node.Children = append(node.Children, childNode)
+		ids = append(ids, childNode.Ids)
+	}
+
+	node.Ids = strings.Join(ids, " ")
+	return node
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"strings"
+	"testing"
+)
+
+func TestWriteHTMLReport(t *testing.T) {
+	reg, err := referencedata.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := writeHTMLReport(&buf, reg); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	out := buf.String()
+	for _, s := range []string{"<html", "<table", "<td>320</td><td>A320</td><td>Airbus A320</td>"} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("expected report to contain %q", s)
+			return
+		}
+	}
+
+	for _, s := range []string{"<script src=", "<link ", "https://"} {
+		if strings.Contains(out, s) {
+			t.Fatalf("expected report to have no external dependencies, found %q", s)
+			return
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"golang.org/x/sync/errgroup"
+	"log"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// runServe implements the serve subcommand, which serves lookups over HTTP until ctx is done.
+func runServe(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("reference-data serve", flag.ContinueOnError)
+	addr := flags.String("addr", ":8080", "address to listen on")
+	grpcAddr := flags.String("grpc-addr", "", "address to serve the gRPC API on, disabled if empty")
+	dir := flags.String("dir", "", "directory containing the csv files to serve, reloaded on change; the embedded data is served if empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *dir != "" {
+		if *grpcAddr != "" {
+			return errors.New("--grpc-addr is not supported together with --dir")
+		}
+
+		return WatchAndServe(ctx, *addr, *dir)
+	}
+
+	reg, err := referencedata.NewRegistry()
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           newServer(reg),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			return err
+		}
+
+		grpcSrv := newGRPCServer(reg)
+		go func() {
+			<-ctx.Done()
+			grpcSrv.GracefulStop()
+		}()
+
+		go func() {
+			log.Printf("serving gRPC on %s", *grpcAddr)
+			if err := grpcSrv.Serve(lis); err != nil {
+				log.Printf("gRPC server stopped: %v", err)
+			}
+		}()
+	}
+
+	return listenAndServe(ctx, srv)
+}
+
+// WatchAndServe serves the csv files in dir over HTTP on addr until ctx is done.
+// The registry is reloaded whenever one of the files changes. Requests in flight complete against the
+// registry they started with, later requests see the reloaded one. If a reload fails, the previous registry is kept.
+func WatchAndServe(ctx context.Context, addr string, dir string) error {
+	reg, err := referencedata.NewRegistryFromDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var current atomic.Pointer[referencedata.Registry]
+	current.Store(reg)
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           newReloadingServer(current.Load),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return watchRegistry(ctx, dir, &current, nil, nil)
+	})
+	g.Go(func() error {
+		return listenAndServe(ctx, srv)
+	})
+
+	return g.Wait()
+}
+
+// watchRegistry replaces the registry in current with the one loaded from dir whenever one of its csv files changes, until ctx is done.
+// If they are not nil, ready is called once the files are watched and reloaded after every successful reload.
+func watchRegistry(ctx context.Context, dir string, current *atomic.Pointer[referencedata.Registry], ready, reloaded func()) error {
+	paths := []string{
+		filepath.Join(dir, "aircraft_types.csv"),
+		filepath.Join(dir, "aircraft_families.csv"),
+		filepath.Join(dir, "aircraft_aliases.csv"),
+	}
+
+	return watchFiles(ctx, paths, ready, func() {
+		reg, err := referencedata.NewRegistryFromDir(dir)
+		if err != nil {
+			log.Printf("failed to reload %s, keeping the previous data: %v", dir, err)
+			return
+		}
+
+		current.Store(reg)
+		log.Printf("reloaded %s", dir)
+		if reloaded != nil {
+			reloaded()
+		}
+	})
+}
+
+// listenAndServe runs srv until ctx is done and shuts it down gracefully afterwards.
+func listenAndServe(ctx context.Context, srv *http.Server) error {
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("failed to shut down server: %v", err)
+		}
+	}()
+
+	log.Printf("listening on %s", srv.Addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// newServer returns the handler serving the lookup endpoints of reg.
+func newServer(reg *referencedata.Registry) http.Handler {
+	return newReloadingServer(func() *referencedata.Registry { return reg })
+}
+
+// newReloadingServer returns the handler serving the lookup endpoints of the registry returned by current.
+// current is called once per request, so a request is answered from a single registry.
+func newReloadingServer(current func() *referencedata.Registry) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /aircraft", func(w http.ResponseWriter, r *http.Request) {
+		iata := r.URL.Query().Get("iata")
+		if iata == "" {
+			writeError(w, http.StatusBadRequest, "missing iata parameter")
+			return
+		}
+
+		res, err := current().LookupByIATA(referencedata.IATA(iata))
+		if err != nil {
+			writeLookupError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, res)
+	})
+
+	mux.HandleFunc("GET /families/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f, ok := current().Family(r.PathValue("id"))
+		if !ok {
+			writeLookupError(w, referencedata.ErrNotFound)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, f)
+	})
+
+	mux.HandleFunc("GET /aliases/{iata}", func(w http.ResponseWriter, r *http.Request) {
+		codes, err := current().AllAliasesFor(referencedata.IATA(r.PathValue("iata")))
+		if err != nil {
+			writeLookupError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, codes)
+	})
+
+	return mux
+}
+
+func writeLookupError(w http.ResponseWriter, err error) {
+	if errors.Is(err, referencedata.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
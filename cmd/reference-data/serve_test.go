@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestServer(t *testing.T) {
+	reg, err := referencedata.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	srv := httptest.NewServer(newServer(reg))
+	t.Cleanup(srv.Close)
+
+	var res referencedata.LookupResult
+	getJSON(t, srv.URL+"/aircraft?iata=20N", http.StatusOK, &res)
+	if res.Type == nil || res.Type.Id != "32N" {
+		t.Fatalf("expected aircraft type 32N, got %+v", res)
+		return
+	}
+
+	var f referencedata.AircraftFamily
+	getJSON(t, srv.URL+"/families/737", http.StatusOK, &f)
+	if f.Id != "737" || f.Name == "" {
+		t.Fatalf("expected family 737, got %+v", f)
+		return
+	}
+
+	var codes []referencedata.IATA
+	getJSON(t, srv.URL+"/aliases/32N", http.StatusOK, &codes)
+	if !slices.Contains(codes, "20N") || !slices.Contains(codes, "32N") {
+		t.Fatalf("expected 20N and 32N, got %v", codes)
+		return
+	}
+
+	for _, path := range []string{"/aircraft?iata=ZZZ", "/families/does-not-exist", "/aliases/ZZZ"} {
+		var body map[string]string
+		getJSON(t, srv.URL+path, http.StatusNotFound, &body)
+		if body["error"] != "not found" {
+			t.Fatalf("expected not found error for %s, got %v", path, body)
+			return
+		}
+	}
+}
+
+func TestWatchAndServe(t *testing.T) {
+	dir := t.TempDir()
+	typesPath := filepath.Join(dir, "aircraft_types.csv")
+	for path, data := range map[string]string{
+		typesPath: "id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n",
+		filepath.Join(dir, "aircraft_families.csv"): "id,iata,icao,parent_family,level,name\n",
+		filepath.Join(dir, "aircraft_aliases.csv"):  "alias,aircraft_type,aircraft_family\n",
+	} {
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+			return
+		}
+	}
+
+	reg, err := referencedata.NewRegistryFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var current atomic.Pointer[referencedata.Registry]
+	current.Store(reg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ready := make(chan struct{})
+	reloaded := make(chan struct{}, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- watchRegistry(ctx, dir, &current, func() { close(ready) }, func() {
+			select {
+			case reloaded <- struct{}{}:
+			default:
+			}
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	srv := httptest.NewServer(newReloadingServer(current.Load))
+	t.Cleanup(srv.Close)
+
+	var body map[string]string
+	getJSON(t, srv.URL+"/aircraft?iata=321", http.StatusNotFound, &body)
+
+	select {
+	case <-ready:
+	case err := <-done:
+		t.Fatalf("watcher stopped before it was ready: %v", err)
+		return
+	}
+
+	if err := os.WriteFile(typesPath, []byte("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n321,,321,A321,Airbus A321\n"), 0644); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	select {
+	case <-reloaded:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the registry to be reloaded after the change")
+		return
+	}
+
+	var res referencedata.LookupResult
+	getJSON(t, srv.URL+"/aircraft?iata=321", http.StatusOK, &res)
+	if res.Type == nil || res.Type.Name != "Airbus A321" {
+		t.Fatalf("expected Airbus A321, got %+v", res)
+		return
+	}
+}
+
+func getJSON(t *testing.T, url string, expectedStatus int, v any) {
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		t.Fatalf("expected status %d for %s, got %d", expectedStatus, url, resp.StatusCode)
+		return
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+		return
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/explore-flights/reference-data/internal/atomicfile"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"github.com/explore-flights/reference-data/pkg/referencedata/cmd"
+)
+
+// runSnapshot implements the snapshot subcommand, which writes a snapshot of the embedded CSV files.
+func runSnapshot(_ context.Context, args []string) error {
+	flags := flag.NewFlagSet("reference-data snapshot", flag.ContinueOnError)
+	common := cmd.CommonFlags{Output: "registry.gob"}
+	common.RegisterOutput(flags, "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	reg, err := referencedata.NewRegistry()
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.Write(common.Output, reg.WriteSnapshot)
+}
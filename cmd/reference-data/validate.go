@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"io"
+	"os"
+)
+
+// runValidate implements the validate subcommand, which checks the embedded files.
+func runValidate(_ context.Context, args []string) error {
+	flags := flag.NewFlagSet("reference-data validate", flag.ContinueOnError)
+	warnings := flags.Bool("warnings", false, "also print inconsistencies which don't fail validation")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	err := referencedata.ValidateEmbedded()
+	if *warnings {
+		// the registry can't be built if the files can't be parsed, which is already reported by err
+		if reg, regErr := referencedata.NewRegistry(); regErr == nil {
+			if err := writeWarningsText(os.Stdout, reg.InconsistencyReport()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return err
+}
+
+func writeWarningsText(w io.Writer, warnings []referencedata.InconsistencyWarning) error {
+	for _, warning := range warnings {
+		if _, err := fmt.Fprintf(w, "warning [%s] aircraft type %q: %s (%s = %q)\n", warning.Severity, warning.TypeId, warning.Reason, warning.FieldName, warning.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"testing"
+)
+
+func TestWriteWarningsText(t *testing.T) {
+	warnings := []referencedata.InconsistencyWarning{{
+		TypeId:    "310",
+		FieldName: "typical_seats",
+		Value:     "180",
+		Reason:    "widebody with fewer than 200 seats",
+		Severity:  referencedata.WarningSeverityLow,
+	}}
+
+	var buf bytes.Buffer
+	if err := writeWarningsText(&buf, warnings); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	expected := "warning [low] aircraft type \"310\": widebody with fewer than 200 seats (typical_seats = \"180\")\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+		return
+	}
+}
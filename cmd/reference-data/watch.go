@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"github.com/fsnotify/fsnotify"
+	"log"
+	"path/filepath"
+	"time"
+)
+
+// watchDebounce is the time to wait after the last change before calling the watch callback.
+const watchDebounce = 200 * time.Millisecond
+
+// watchFiles calls onChange whenever one of the files at paths changes, until ctx is done.
+// The parent directories are watched instead of the files themselves, so that editors replacing a file on save are picked up.
+// If ready is not nil it is called once all files are watched.
+func watchFiles(ctx context.Context, paths []string, ready func(), onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	watched := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		path, err = filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+
+		watched[path] = struct{}{}
+		if err := watcher.Add(filepath.Dir(path)); err != nil {
+			return err
+		}
+	}
+
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	if ready != nil {
+		ready()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if _, ok := watched[filepath.Clean(event.Name)]; ok && event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+				timer.Reset(watchDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			log.Printf("watch error: %v", err)
+
+		case <-timer.C:
+			onChange()
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMainGraphWatch(t *testing.T) {
+	dir := t.TempDir()
+	typesPath := filepath.Join(dir, "aircraft_types.csv")
+	familiesPath := filepath.Join(dir, "aircraft_families.csv")
+	aliasesPath := filepath.Join(dir, "aircraft_aliases.csv")
+	output := filepath.Join(dir, "graph.svg")
+
+	for path, data := range map[string]string{
+		typesPath:    "id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n",
+		familiesPath: "id,iata,icao,parent_family,level,name\n",
+		aliasesPath:  "alias,aircraft_type,aircraft_family\n",
+	} {
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, []string{"--watch", "--types", typesPath, "--families", familiesPath, "--aliases", aliasesPath, "-o", output})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	if !waitForFile(output, []byte("Airbus A320"), 30*time.Second) {
+		t.Fatal("initial graph was not rendered")
+		return
+	}
+
+	// give the watcher time to start after the initial render
+	time.Sleep(500 * time.Millisecond)
+
+	if err := os.WriteFile(typesPath, []byte("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n321,,321,A321,Airbus A321\n"), 0644); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if !waitForFile(output, []byte("Airbus A321"), 2*time.Second) {
+		t.Fatal("graph was not re-rendered within 2 seconds")
+		return
+	}
+}
+
+// waitForFile polls the file at path until it contains content or the timeout elapses.
+func waitForFile(path string, content []byte, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if b, err := os.ReadFile(path); err == nil && bytes.Contains(b, content) {
+			return true
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return false
+}
+
+func TestMainGraphWatchDefaultPaths(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(watchDefaultDir, 0755); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for name, data := range map[string]string{
+		"aircraft_types.csv":    "id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n",
+		"aircraft_families.csv": "id,iata,icao,parent_family,level,name\n",
+		"aircraft_aliases.csv":  "alias,aircraft_type,aircraft_family\n",
+	} {
+		if err := os.WriteFile(filepath.Join(watchDefaultDir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, []string{"--watch", "-o", "graph.svg"})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	if !waitForFile("graph.svg", []byte("Airbus A320"), 30*time.Second) {
+		t.Fatal("expected the graph to be rendered from the files in pkg/referencedata")
+		return
+	}
+}
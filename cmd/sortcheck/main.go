@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+)
+
+// sortcheck verifies that CSV files are sorted by a column or by several columns compared in order.
+// Each argument has the form <file>:<column>[,<column>...].
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("usage: sortcheck <file>:<column>[,<column>...]...")
+		return
+	}
+
+	for _, arg := range os.Args[1:] {
+		name, columns, ok := strings.Cut(arg, ":")
+		if !ok {
+			log.Fatalf("invalid argument %q, expected <file>:<column>[,<column>...]", arg)
+			return
+		}
+
+		if err := checkSorted(name, strings.Split(columns, ",")...); err != nil {
+			log.Fatal(err)
+			return
+		}
+	}
+}
+
+// checkSorted returns an error unless the rows of the file are strictly sorted by the values of columns.
+func checkSorted(name string, columns ...string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	headers, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("%s: failed to read header: %w", name, err)
+	}
+
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		indexes[i] = slices.Index(headers, column)
+		if indexes[i] == -1 {
+			return fmt.Errorf("%s: column %q not found", name, column)
+		}
+	}
+
+	var prev []string
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		key := make([]string, len(indexes))
+		for i, idx := range indexes {
+			key[i] = record[idx]
+		}
+
+		if line > 1 && slices.Compare(key, prev) <= 0 {
+			return fmt.Errorf("%s: %s %q in line %d is not sorted after %q", name, strings.Join(columns, ","), key, line, prev)
+		}
+
+		prev = key
+	}
+}
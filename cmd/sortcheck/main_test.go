@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCSVFilesAreSorted(t *testing.T) {
+	for name, columns := range map[string][]string{
+		"aircraft_aliases.csv":               {"alias"},
+		"aircraft_regulatory.csv":            {"aircraft_type_id", "authority"},
+		"aircraft_seat_configurations.csv":   {"aircraft_type_id", "config_name"},
+		"aircraft_families.csv":              {"id"},
+		"aircraft_types.csv":                 {"id"},
+		"aircraft_types_deprecation_log.csv": {"old_iata", "new_iata"},
+		"aircraft_type_performance.csv":      {"aircraft_type_id"},
+		"aircraft_type_photos.csv":           {"aircraft_type_id", "url"},
+		"aircraft_variants.csv":              {"id"},
+		"countries.csv":                      {"code"},
+		"historical_aliases.csv":             {"historical_iata"},
+	} {
+		if err := checkSorted(filepath.Join("..", "..", "pkg", "referencedata", name), columns...); err != nil {
+			t.Fatal(err)
+			return
+		}
+	}
+}
+
+func TestCheckSortedDetectsUnsortedRow(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "unsorted.csv")
+	if err := os.WriteFile(name, []byte("id,name\nA,first\nC,second\nB,third\n"), 0644); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err := checkSorted(name, "id"); err == nil {
+		t.Fatal("expected unsorted row to be detected")
+		return
+	}
+}
+
+func TestCheckSortedMultipleColumns(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "sorted.csv")
+	if err := os.WriteFile(name, []byte("id,url,name\nA,b,first\nA,c,second\nB,a,third\n"), 0644); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err := checkSorted(name, "id", "url"); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err := checkSorted(name, "id"); err == nil {
+		t.Fatal("expected duplicate id to be detected without the url column")
+		return
+	}
+}
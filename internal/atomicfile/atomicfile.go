@@ -0,0 +1,32 @@
+// Package atomicfile replaces files without exposing partially written content to readers.
+package atomicfile
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Write writes the output of write to a temporary file next to path and renames it to path once complete.
+func Write(path string, write func(w io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := f.Chmod(0644); err != nil {
+		return err
+	}
+
+	if err := write(f); err != nil {
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,49 @@
+// Package cmd provides the flags shared by the reference-data commands.
+package cmd
+
+import (
+	"flag"
+)
+
+// CommonFlags are the flags shared by the commands rendering or exporting the registry.
+type CommonFlags struct {
+	Output string
+	Format string
+	Family string
+	Depth  int
+}
+
+// Usage overrides the help texts of the common flags whose meaning depends on the command.
+// Empty fields keep the generic help text.
+type Usage struct {
+	Output string
+	Format string
+}
+
+// Register adds all common flags to flags, using the current values of c as their defaults.
+func (c *CommonFlags) Register(flags *flag.FlagSet, usage Usage) {
+	c.RegisterOutput(flags, usage.Output)
+	c.RegisterFormat(flags, usage.Format)
+	flags.StringVar(&c.Family, "family", c.Family, "only include the family with this id, its descendants and their aliases")
+	flags.IntVar(&c.Depth, "depth", c.Depth, "only include families at most this many levels below a root family, zero means no limit")
+}
+
+// RegisterOutput adds only the output flag to flags for commands which neither filter nor choose a format.
+// Output can be set with both -o and -output, an empty usage keeps the generic help text.
+func (c *CommonFlags) RegisterOutput(flags *flag.FlagSet, usage string) {
+	if usage == "" {
+		usage = "output file path"
+	}
+
+	flags.StringVar(&c.Output, "o", c.Output, usage)
+	flags.StringVar(&c.Output, "output", c.Output, usage)
+}
+
+// RegisterFormat adds only the format flag to flags, an empty usage keeps the generic help text.
+func (c *CommonFlags) RegisterFormat(flags *flag.FlagSet, usage string) {
+	if usage == "" {
+		usage = "output format"
+	}
+
+	flags.StringVar(&c.Format, "format", c.Format, usage)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"flag"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCommonFlagsRegister(t *testing.T) {
+	var c CommonFlags
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	c.Register(flags, Usage{})
+	if err := flags.Parse([]string{"-o", "out.svg", "--format=svg", "--family", "32S", "--depth=2", "extra"}); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	expected := CommonFlags{Output: "out.svg", Format: "svg", Family: "32S", Depth: 2}
+	if c != expected {
+		t.Fatalf("expected %+v, got %+v", expected, c)
+		return
+	}
+
+	if !slices.Equal(flags.Args(), []string{"extra"}) {
+		t.Fatalf("expected the remaining argument extra, got %v", flags.Args())
+		return
+	}
+}
+
+func TestCommonFlagsRegisterOutputOnly(t *testing.T) {
+	c := CommonFlags{Output: "report.html"}
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	c.RegisterOutput(flags, "")
+
+	err := flags.Parse([]string{"--family=32S"})
+	if err == nil {
+		t.Fatal("expected error for a flag which is not registered")
+		return
+	}
+
+	if !strings.Contains(err.Error(), "-family") {
+		t.Fatalf("expected the unknown flag in the error, got %v", err)
+		return
+	}
+
+	if c.Output != "report.html" {
+		t.Fatalf("expected the default output, got %q", c.Output)
+		return
+	}
+}
+
+func TestCommonFlagsRegisterDefaults(t *testing.T) {
+	c := CommonFlags{Format: "svg"}
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	c.Register(flags, Usage{})
+	if err := flags.Parse([]string{"--output", "out.svg"}); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if c.Format != "svg" || c.Output != "out.svg" {
+		t.Fatalf("expected the default format and the parsed output, got %+v", c)
+		return
+	}
+}
+
+func TestCommonFlagsRegisterUsage(t *testing.T) {
+	var c CommonFlags
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	c.Register(flags, Usage{Format: "output format, one of svg or dot"})
+
+	if usage := flags.Lookup("format").Usage; usage != "output format, one of svg or dot" {
+		t.Fatalf("expected the given format usage, got %q", usage)
+		return
+	}
+
+	for _, name := range []string{"o", "output"} {
+		if usage := flags.Lookup(name).Usage; usage != "output file path" {
+			t.Fatalf("expected the generic usage for -%s, got %q", name, usage)
+			return
+		}
+	}
+}
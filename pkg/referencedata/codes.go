@@ -0,0 +1,54 @@
+package referencedata
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	iataPattern = regexp.MustCompile(`^[A-Z0-9]{3}$`)
+	icaoPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,3}$`)
+	// countryCodePattern matches ISO 3166-1 alpha-2 country codes.
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// IATA is a three character IATA aircraft type code.
+type IATA string
+
+// ICAO is a two to four character ICAO aircraft type designator.
+type ICAO string
+
+// NewIATA validates s and returns it as an IATA code.
+func NewIATA(s string) (IATA, error) {
+	if !iataPattern.MatchString(s) {
+		return "", fmt.Errorf("invalid iata code %q", s)
+	}
+
+	return IATA(s), nil
+}
+
+// NewICAO validates s and returns it as an ICAO designator.
+func NewICAO(s string) (ICAO, error) {
+	if !icaoPattern.MatchString(s) {
+		return "", fmt.Errorf("invalid icao code %q", s)
+	}
+
+	return ICAO(s), nil
+}
+
+// NormalizeIATA accepts an IATA code as commonly found in flight data, in lowercase, surrounded by whitespace
+// or with a leading slash, and validates it like NewIATA.
+func NormalizeIATA(code string) (IATA, error) {
+	return NewIATA(normalizeCode(code))
+}
+
+// NormalizeICAO accepts an ICAO designator with the same variations as NormalizeIATA and validates it like NewICAO.
+func NormalizeICAO(code string) (ICAO, error) {
+	return NewICAO(normalizeCode(code))
+}
+
+// normalizeCode trims whitespace, strips a leading slash and uppercases code.
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(code), "/"))
+}
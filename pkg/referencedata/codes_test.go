@@ -0,0 +1,97 @@
+package referencedata
+
+import (
+	"testing"
+)
+
+func TestNewIATA(t *testing.T) {
+	for _, c := range []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "320"},
+		{value: "32N"},
+		{value: "", wantErr: true},
+		{value: "32", wantErr: true},
+		{value: "3200", wantErr: true},
+		{value: "32n", wantErr: true},
+	} {
+		if _, err := NewIATA(c.value); (err != nil) != c.wantErr {
+			t.Fatalf("NewIATA(%q): expected error %v, got %v", c.value, c.wantErr, err)
+			return
+		}
+	}
+}
+
+func TestNewICAO(t *testing.T) {
+	for _, c := range []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "A320"},
+		{value: "B77W"},
+		{value: "DH8"},
+		{value: "", wantErr: true},
+		{value: "A", wantErr: true},
+		{value: "A3200", wantErr: true},
+		{value: "3200", wantErr: true},
+	} {
+		if _, err := NewICAO(c.value); (err != nil) != c.wantErr {
+			t.Fatalf("NewICAO(%q): expected error %v, got %v", c.value, c.wantErr, err)
+			return
+		}
+	}
+}
+
+func TestNormalizeIATA(t *testing.T) {
+	for _, c := range []struct {
+		value    string
+		expected IATA
+		wantErr  bool
+	}{
+		{value: "32N", expected: "32N"},
+		{value: "32n", expected: "32N"},
+		{value: " 320\t", expected: "320"},
+		{value: "/73h", expected: "73H"},
+		{value: " /320 ", expected: "320"},
+		{value: "32", wantErr: true},
+		{value: "//320", wantErr: true},
+	} {
+		code, err := NormalizeIATA(c.value)
+		if (err != nil) != c.wantErr {
+			t.Fatalf("NormalizeIATA(%q): expected error %v, got %v", c.value, c.wantErr, err)
+			return
+		}
+
+		if code != c.expected {
+			t.Fatalf("NormalizeIATA(%q): expected %q, got %q", c.value, c.expected, code)
+			return
+		}
+	}
+}
+
+func TestNormalizeICAO(t *testing.T) {
+	for _, c := range []struct {
+		value    string
+		expected ICAO
+		wantErr  bool
+	}{
+		{value: "A320", expected: "A320"},
+		{value: "b77w", expected: "B77W"},
+		{value: "  A20N ", expected: "A20N"},
+		{value: "/a21n", expected: "A21N"},
+		{value: "a3200", wantErr: true},
+		{value: "/3200", wantErr: true},
+	} {
+		code, err := NormalizeICAO(c.value)
+		if (err != nil) != c.wantErr {
+			t.Fatalf("NormalizeICAO(%q): expected error %v, got %v", c.value, c.wantErr, err)
+			return
+		}
+
+		if code != c.expected {
+			t.Fatalf("NormalizeICAO(%q): expected %q, got %q", c.value, c.expected, code)
+			return
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Package referencedata provides aircraft reference data, the aircraft types, families and aliases,
+// together with a Registry to look them up by IATA and ICAO code and to traverse the family hierarchy.
+package referencedata
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+)
+
+//go:generate go run ../../cmd/sortcheck aircraft_aliases.csv:alias aircraft_families.csv:id aircraft_types.csv:id aircraft_variants.csv:id historical_aliases.csv:historical_iata countries.csv:code aircraft_type_performance.csv:aircraft_type_id aircraft_type_photos.csv:aircraft_type_id,url aircraft_types_deprecation_log.csv:old_iata,new_iata aircraft_regulatory.csv:aircraft_type_id,authority aircraft_seat_configurations.csv:aircraft_type_id,config_name
+
+//go:embed aircraft_aliases.csv
+var aliases string
+
+//go:embed aircraft_families.csv
+var families string
+
+//go:embed aircraft_types.csv
+var types string
+
+//go:embed aircraft_type_names.csv
+var typeNames string
+
+//go:embed aircraft_variants.csv
+var variants string
+
+//go:embed aircraft_types_deprecation_log.csv
+var deprecationLog string
+
+//go:embed aircraft_regulatory.csv
+var regulatory string
+
+//go:embed aircraft_type_performance.csv
+var performance string
+
+//go:embed aircraft_type_photos.csv
+var photos string
+
+//go:embed aircraft_seat_configurations.csv
+var seatConfigurations string
+
+//go:embed historical_aliases.csv
+var historicalAliases string
+
+//go:embed countries.csv
+var countries string
+
+// ReadCSV yields the rows of the csv file in reader after the header as maps from column name to value.
+// Iteration stops at the first error, which is stored in outErr.
+func ReadCSV(reader io.Reader, outErr *error) iter.Seq2[int, map[string]string] {
+	return readCsvTyped(reader, func(headers, record []string) (map[string]string, error) {
+		row := make(map[string]string, len(headers))
+		for i, colName := range headers {
+			if i < len(record) {
+				row[colName] = record[i]
+			}
+		}
+
+		return row, nil
+	}, outErr)
+}
+
+// readCsvTyped yields the result of parse for every row after the header.
+// The record slice passed to parse is reused for the next row, so parse must not retain it; the strings in it are safe to keep.
+// Iteration stops at the first error, which is stored in outErr.
+func readCsvTyped[T any](reader io.Reader, parse func(headers, record []string) (T, error), outErr *error) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		r := csv.NewReader(reader)
+		r.ReuseRecord = true
+
+		headers, err := r.Read()
+		if err != nil {
+			*outErr = fmt.Errorf("failed to read header: %w", err)
+			return
+		}
+
+		headers = slices.Clone(headers)
+
+		line := 1
+		for {
+			record, err := r.Read()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+
+				*outErr = err
+				break
+			}
+
+			v, err := parse(headers, record)
+			if err != nil {
+				*outErr = fmt.Errorf("line %d: %w", line, err)
+				break
+			}
+
+			if !yield(line, v) {
+				break
+			}
+
+			line++
+		}
+	}
+}
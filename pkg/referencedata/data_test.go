@@ -0,0 +1,1240 @@
+package referencedata
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// maxFamilyDepth is the maximum number of levels a family hierarchy may have, counting the root family as one level.
+const maxFamilyDepth = 5
+
+// The minimum numbers of rows of the embedded csv files. They are well below the current counts and only guard
+// against accidentally truncated files.
+const (
+	minAircraftTypes = 50
+	minFamilies      = 10
+	minAliases       = 1
+)
+
+// maxAliasesPerType bounds the number of aliases per aircraft type checked by TestAliasCountPerType. It is far above
+// the few aliases a type is known by in practice, more aliases hint at duplicated or misassigned ones.
+// There is no lower bound of the mean: the embedded data records 3 aliases for 431 aircraft types, so a mean of 1.5
+// would need far more alias data than is available and a lower bound would only check the current state of the file.
+const maxAliasesPerType = 20
+
+// familiesWithoutTypes lists the families which are allowed to have no aircraft types. They are IATA group codes
+// whose aircraft types are already assigned to the families of their models.
+var familiesWithoutTypes = []string{"14F", "73F", "D8F", "D9F", "JST"}
+
+// photoLicenses lists the SPDX identifiers of the licenses accepted for aircraft photos.
+var photoLicenses = []string{
+	"CC0-1.0",
+	"CC-BY-2.0",
+	"CC-BY-3.0",
+	"CC-BY-4.0",
+	"CC-BY-SA-2.0",
+	"CC-BY-SA-3.0",
+	"CC-BY-SA-4.0",
+}
+
+// localePattern matches BCP-47 language tags like de, zh-Hans or pt-BR.
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+type readerAndIdColumn struct {
+	reader    io.Reader
+	idColumn  string
+	allowNull bool
+}
+
+func TestIdsAreUnique(t *testing.T) {
+	testIdsAreUnique(t, readerAndIdColumn{reader: strings.NewReader(aliases), idColumn: "alias"})
+	testIdsAreUnique(t, readerAndIdColumn{reader: strings.NewReader(families), idColumn: "id"})
+	testIdsAreUnique(t, readerAndIdColumn{reader: strings.NewReader(types), idColumn: "id"})
+	testIdsAreUnique(t, readerAndIdColumn{reader: strings.NewReader(variants), idColumn: "id"})
+	testIdsAreUnique(t, readerAndIdColumn{reader: strings.NewReader(countries), idColumn: "code"})
+	testIdsAreUnique(t, readerAndIdColumn{reader: strings.NewReader(performance), idColumn: "aircraft_type_id"})
+	testIdsAreUnique(
+		t,
+		readerAndIdColumn{reader: strings.NewReader(types), idColumn: "iata"},
+		readerAndIdColumn{reader: strings.NewReader(aliases), idColumn: "alias"},
+		readerAndIdColumn{reader: strings.NewReader(families), idColumn: "iata", allowNull: true},
+		readerAndIdColumn{reader: strings.NewReader(historicalAliases), idColumn: "historical_iata"},
+	)
+}
+
+func TestSchemas(t *testing.T) {
+	for _, c := range embeddedFiles {
+		if err := ValidateSchema(strings.NewReader(c.content), c.schema); err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+			return
+		}
+	}
+}
+
+func TestCSVEncoding(t *testing.T) {
+	for _, c := range embeddedFiles {
+		if !utf8.ValidString(c.content) {
+			t.Fatalf("%s: invalid utf-8", c.name)
+			return
+		}
+
+		if i := strings.IndexByte(c.content, '\r'); i != -1 {
+			t.Fatalf("%s: carriage return at byte %d, expected unix line endings", c.name, i)
+			return
+		}
+
+		if strings.HasPrefix(c.content, "\xEF\xBB\xBF") {
+			t.Fatalf("%s: starts with a byte order mark", c.name)
+			return
+		}
+	}
+}
+
+func TestMinimumRowCounts(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, c := range []struct {
+		name string
+		rows int
+		min  int
+	}{
+		{name: "aircraft_types.csv", rows: len(reg.Types()), min: minAircraftTypes},
+		{name: "aircraft_families.csv", rows: len(reg.Families()), min: minFamilies},
+		{name: "aircraft_aliases.csv", rows: len(reg.Aliases()), min: minAliases},
+	} {
+		if c.rows < c.min {
+			t.Fatalf("%s: expected at least %d rows, got %d", c.name, c.min, c.rows)
+			return
+		}
+	}
+}
+
+func TestAliasCountPerType(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	aircraftTypes := reg.Types()
+	if len(aircraftTypes) == 0 {
+		t.Fatal("expected aircraft types")
+		return
+	}
+
+	histogram := make(map[int]int)
+	for _, at := range aircraftTypes {
+		count := len(reg.aliasesByTypeId[at.Id])
+		if count > maxAliasesPerType {
+			t.Errorf("aircraft type %q has %d aliases, expected at most %d", at.Id, count, maxAliasesPerType)
+		}
+
+		histogram[count]++
+	}
+
+	if testing.Verbose() {
+		for _, count := range slices.Sorted(maps.Keys(histogram)) {
+			t.Logf("%2d aliases: %d aircraft types", count, histogram[count])
+		}
+	}
+}
+
+func TestAliasesXor(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(aliases), &err) {
+		isType := row["aircraft_type"] != ""
+		isFamily := row["aircraft_family"] != ""
+
+		if isType && isFamily {
+			t.Fatalf("both type and family are set in line %d", line)
+			return
+		} else if !isType && !isFamily {
+			t.Fatalf("neither type nor family are set in line %d", line)
+			return
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestICAOFamilyCodes(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(families), &err) {
+		if icao := row["icao"]; icao != "" && !icaoPattern.MatchString(icao) {
+			t.Fatalf("invalid icao %q in line %d", icao, line)
+			return
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestVariantICAOCodes(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(variants), &err) {
+		if icao := row["icao"]; icao != "" && !icaoPattern.MatchString(icao) {
+			t.Fatalf("invalid icao %q in line %d", icao, line)
+			return
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestAircraftTypeNames(t *testing.T) {
+	aircraftIds := make(map[string]struct{})
+	var err error
+	for _, row := range ReadCSV(strings.NewReader(types), &err) {
+		aircraftIds[row["id"]] = struct{}{}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	seen := make(map[string]struct{})
+	for line, row := range ReadCSV(strings.NewReader(typeNames), &err) {
+		if _, ok := aircraftIds[row["aircraft_type_id"]]; !ok {
+			t.Fatalf("unknown aircraft_type_id %q in line %d", row["aircraft_type_id"], line)
+			return
+		}
+
+		if !localePattern.MatchString(row["locale"]) {
+			t.Fatalf("invalid locale %q in line %d", row["locale"], line)
+			return
+		}
+
+		key := row["aircraft_type_id"] + "/" + strings.ToLower(row["locale"])
+		if _, ok := seen[key]; ok {
+			t.Fatalf("duplicate name for %s in line %d", key, line)
+			return
+		}
+
+		seen[key] = struct{}{}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestBodyTypes(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(types), &err) {
+		if bt := row["body_type"]; bt != "" && !slices.Contains(bodyTypes, BodyType(bt)) {
+			t.Fatalf("invalid body_type %q in line %d", bt, line)
+			return
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestEngines(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(types), &err) {
+		if v := row["engine_count"]; v != "" {
+			if n, err := strconv.Atoi(v); err != nil || n < 1 || n > 6 {
+				t.Fatalf("invalid engine_count %q in line %d", v, line)
+				return
+			}
+		}
+
+		if et := row["engine_type"]; et != "" && !slices.Contains(engineTypes, EngineType(et)) {
+			t.Fatalf("invalid engine_type %q in line %d", et, line)
+			return
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestWTCValues(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(types), &err) {
+		if wtc := row["wtc"]; wtc != "" && !slices.Contains(wakeTurbulenceCategories, wtc) {
+			t.Fatalf("invalid wtc %q in line %d", wtc, line)
+			return
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestWTCConsistency(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(types), &err) {
+		wtc := row["wtc"]
+		if row["body_type"] == string(BodyTypeWidebody) && wtc != "H" && wtc != "J" {
+			t.Fatalf("widebody aircraft type %q in line %d has wtc %q, expected H or J", row["id"], line, wtc)
+			return
+		}
+
+		if row["engine_count"] == "1" && row["engine_type"] == string(EngineTypePiston) && wtc != "" && wtc != "L" {
+			t.Fatalf("single engine piston aircraft type %q in line %d has wtc %q, expected L", row["id"], line, wtc)
+			return
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestManufacturerRegion(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(families), &err) {
+		if region := row["manufacturer_region"]; region != "" && !slices.Contains(manufacturerRegions, ManufacturerRegion(region)) {
+			t.Fatalf("invalid manufacturer_region %q in line %d", region, line)
+			return
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestCountries(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(countries), &err) {
+		if code := row["code"]; !countryCodePattern.MatchString(code) {
+			t.Fatalf("invalid code %q in line %d", code, line)
+			return
+		}
+
+		if row["name"] == "" {
+			t.Fatalf("name is empty in line %d", line)
+			return
+		}
+
+		if region := row["region"]; !slices.Contains(regions, Region(region)) {
+			t.Fatalf("invalid region %q in line %d", region, line)
+			return
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestCategories(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(types), &err) {
+		for _, column := range []string{"cargo_variant", "military"} {
+			if _, err := strconv.ParseBool(row[column]); err != nil {
+				t.Fatalf("invalid %s %q in line %d", column, row[column], line)
+				return
+			}
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestSupersonicConsistency(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(types), &err) {
+		supersonic, err := strconv.ParseBool(row["supersonic"])
+		if err != nil {
+			t.Fatalf("invalid supersonic %q in line %d", row["supersonic"], line)
+			return
+		}
+
+		v := row["max_speed_mach"]
+		if v == "" {
+			continue
+		}
+
+		mach, err := strconv.ParseFloat(v, 64)
+		if err != nil || mach <= 0 {
+			t.Fatalf("invalid max_speed_mach %q in line %d", v, line)
+			return
+		}
+
+		if supersonic != (mach > 1) {
+			t.Fatalf("supersonic is %t but max_speed_mach is %s in line %d", supersonic, v, line)
+			return
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestDeprecation(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(types), &err) {
+		deprecated, err := strconv.ParseBool(row["deprecated"])
+		if err != nil {
+			t.Fatalf("invalid deprecated %q in line %d", row["deprecated"], line)
+			return
+		}
+
+		if v := row["retired_year"]; v != "" {
+			if n, err := strconv.Atoi(v); err != nil || n < 1900 || n > 2100 {
+				t.Fatalf("invalid retired_year %q in line %d", v, line)
+				return
+			} else if !deprecated {
+				t.Fatalf("retired_year is set but deprecated is false in line %d", line)
+				return
+			}
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestDeprecationLogRows(t *testing.T) {
+	header := "old_iata,new_iata,aircraft_type_id,change_year,reason\n"
+	cases := []struct {
+		name           string
+		deprecationLog io.Reader
+		wantErr        bool
+	}{
+		{name: "embedded", deprecationLog: strings.NewReader(deprecationLog)},
+		{name: "valid", deprecationLog: strings.NewReader(header + "32X,320,320,2005,renamed\n")},
+		{name: "external", deprecationLog: strings.NewReader(header + "ZZ1,ZZ2,external,2018,\n")},
+		{name: "short code", deprecationLog: strings.NewReader(header + "32,320,320,2005,\n"), wantErr: true},
+		{name: "long code", deprecationLog: strings.NewReader(header + "32X,3200,320,2005,\n"), wantErr: true},
+		{name: "missing year", deprecationLog: strings.NewReader(header + "32X,320,320,,\n"), wantErr: true},
+		{name: "invalid year", deprecationLog: strings.NewReader(header + "32X,320,320,205,\n"), wantErr: true},
+		{name: "unknown type", deprecationLog: strings.NewReader(header + "32X,320,XXX,2005,\n"), wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkDeprecationLog(c.deprecationLog, strings.NewReader(types))
+			if c.wantErr && err == nil {
+				t.Fatal("expected invalid deprecation log to be detected")
+				return
+			} else if !c.wantErr && err != nil {
+				t.Fatal(err)
+				return
+			}
+		})
+	}
+}
+
+func TestRegulatoryRows(t *testing.T) {
+	header := "aircraft_type_id,authority,certificate_number,issue_year\n"
+	cases := []struct {
+		name       string
+		regulatory io.Reader
+		wantErr    bool
+	}{
+		{name: "embedded", regulatory: strings.NewReader(regulatory)},
+		{name: "valid", regulatory: strings.NewReader(header + "320,EASA,A.064,1988\n320,FAA,A28NM,1988\n")},
+		{name: "other authority", regulatory: strings.NewReader(header + "320,Other,X-1,1990\n")},
+		{name: "unknown authority", regulatory: strings.NewReader(header + "320,LBA,X-1,1990\n"), wantErr: true},
+		{name: "missing year", regulatory: strings.NewReader(header + "320,FAA,A28NM,\n"), wantErr: true},
+		{name: "short year", regulatory: strings.NewReader(header + "320,FAA,A28NM,988\n"), wantErr: true},
+		{name: "non numeric year", regulatory: strings.NewReader(header + "320,FAA,A28NM,19x8\n"), wantErr: true},
+		{name: "unknown type", regulatory: strings.NewReader(header + "XXX,FAA,A28NM,1988\n"), wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkRegulatory(c.regulatory, strings.NewReader(types))
+			if c.wantErr && err == nil {
+				t.Fatal("expected invalid regulatory certificate to be detected")
+				return
+			} else if !c.wantErr && err != nil {
+				t.Fatal(err)
+				return
+			}
+		})
+	}
+}
+
+func TestSeatsAndRange(t *testing.T) {
+	var err error
+	for line, row := range ReadCSV(strings.NewReader(types), &err) {
+		if v := row["typical_seats"]; v != "" {
+			if n, err := strconv.Atoi(v); err != nil || n < 1 || n > 900 {
+				t.Fatalf("invalid typical_seats %q in line %d", v, line)
+				return
+			}
+		}
+
+		if v := row["max_range_km"]; v != "" {
+			if n, err := strconv.Atoi(v); err != nil || n < 1 {
+				t.Fatalf("invalid max_range_km %q in line %d", v, line)
+				return
+			}
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func TestSeatConfigurations(t *testing.T) {
+	fixtureTypes := "id,typical_seats\n320,180\n738,\n"
+	cases := []struct {
+		name    string
+		configs io.Reader
+		types   io.Reader
+		wantErr bool
+	}{
+		{name: "embedded", configs: strings.NewReader(seatConfigurations), types: strings.NewReader(types)},
+		{
+			name:    "valid",
+			configs: strings.NewReader("aircraft_type_id,config_name,first_seats,business_seats,premium_economy_seats,economy_seats\n320,2-class,0,12,0,150\n738,1-class,0,0,0,189\n"),
+			types:   strings.NewReader(fixtureTypes),
+		},
+		{
+			name:    "negative",
+			configs: strings.NewReader("aircraft_type_id,config_name,first_seats,business_seats,premium_economy_seats,economy_seats\n320,2-class,0,-1,0,150\n"),
+			types:   strings.NewReader(fixtureTypes),
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			configs: strings.NewReader("aircraft_type_id,config_name,first_seats,business_seats,premium_economy_seats,economy_seats\n321,2-class,0,12,0,150\n"),
+			types:   strings.NewReader(fixtureTypes),
+			wantErr: true,
+		},
+		{
+			name:    "duplicate",
+			configs: strings.NewReader("aircraft_type_id,config_name,first_seats,business_seats,premium_economy_seats,economy_seats\n320,2-class,0,12,0,150\n320,2-class,0,8,0,160\n"),
+			types:   strings.NewReader(fixtureTypes),
+			wantErr: true,
+		},
+		{
+			name:    "more than typical seats",
+			configs: strings.NewReader("aircraft_type_id,config_name,first_seats,business_seats,premium_economy_seats,economy_seats\n320,2-class,0,12,0,170\n"),
+			types:   strings.NewReader(fixtureTypes),
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkSeatConfigurations(c.configs, c.types)
+			if c.wantErr && err == nil {
+				t.Fatal("expected invalid seat configuration to be detected")
+				return
+			} else if !c.wantErr && err != nil {
+				t.Fatal(err)
+				return
+			}
+		})
+	}
+}
+
+func TestPerformanceValues(t *testing.T) {
+	header := "aircraft_type_id,cruise_speed_kmh,service_ceiling_ft,takeoff_distance_m,landing_distance_m\n"
+	cases := []struct {
+		name        string
+		performance io.Reader
+		wantErr     bool
+	}{
+		{name: "embedded", performance: strings.NewReader(performance)},
+		{name: "valid", performance: strings.NewReader(header + "320,830,39000,2100,1500\n")},
+		{name: "empty value", performance: strings.NewReader(header + "320,830,,2100,1500\n"), wantErr: true},
+		{name: "zero", performance: strings.NewReader(header + "320,0,39000,2100,1500\n"), wantErr: true},
+		{name: "ceiling too high", performance: strings.NewReader(header + "320,830,60000,2100,1500\n"), wantErr: true},
+		{name: "unknown type", performance: strings.NewReader(header + "XXX,830,39000,2100,1500\n"), wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkPerformance(c.performance, strings.NewReader(types))
+			if c.wantErr && err == nil {
+				t.Fatal("expected invalid performance to be detected")
+				return
+			} else if !c.wantErr && err != nil {
+				t.Fatal(err)
+				return
+			}
+		})
+	}
+}
+
+func TestPhotoURLFormat(t *testing.T) {
+	header := "aircraft_type_id,sort_order,url,attribution,license\n"
+	cases := []struct {
+		name    string
+		photos  io.Reader
+		wantErr bool
+	}{
+		{name: "embedded", photos: strings.NewReader(photos)},
+		{name: "valid", photos: strings.NewReader(header + "320,1,https://example.com/a320.jpg,Jane Doe,CC-BY-SA-4.0\n320,2,http://example.com/a320-2.jpg,John Doe,CC0-1.0\n")},
+		{name: "relative url", photos: strings.NewReader(header + "320,1,/a320.jpg,Jane Doe,CC-BY-4.0\n"), wantErr: true},
+		{name: "other scheme", photos: strings.NewReader(header + "320,1,ftp://example.com/a320.jpg,Jane Doe,CC-BY-4.0\n"), wantErr: true},
+		{name: "blank attribution", photos: strings.NewReader(header + "320,1,https://example.com/a320.jpg, ,CC-BY-4.0\n"), wantErr: true},
+		{name: "unknown license", photos: strings.NewReader(header + "320,1,https://example.com/a320.jpg,Jane Doe,all rights reserved\n"), wantErr: true},
+		{name: "unknown type", photos: strings.NewReader(header + "XXX,1,https://example.com/a320.jpg,Jane Doe,CC-BY-4.0\n"), wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkPhotos(c.photos, strings.NewReader(types))
+			if c.wantErr && err == nil {
+				t.Fatal("expected invalid photo to be detected")
+				return
+			} else if !c.wantErr && err != nil {
+				t.Fatal(err)
+				return
+			}
+		})
+	}
+}
+
+func TestNoSelfReferencingFamily(t *testing.T) {
+	cases := []struct {
+		name    string
+		reader  io.Reader
+		wantErr bool
+	}{
+		{name: "embedded", reader: strings.NewReader(families)},
+		{
+			name:    "fixture",
+			reader:  strings.NewReader("id,iata,icao,parent_family,level,name\nA,,,,family,A\nB,,,B,family,B\n"),
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkNoSelfReferencingFamily(c.reader)
+			if c.wantErr && err == nil {
+				t.Fatal("expected self-referencing family to be detected")
+				return
+			} else if !c.wantErr && err != nil {
+				t.Fatal(err)
+				return
+			}
+		})
+	}
+}
+
+func TestMaxFamilyDepth(t *testing.T) {
+	var err error
+	parentFamilyById := make(map[string]string)
+	for _, row := range ReadCSV(strings.NewReader(families), &err) {
+		parentFamilyById[row["id"]] = row["parent_family"]
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for familyId := range parentFamilyById {
+		path := []string{familyId}
+		for parentFamilyId := parentFamilyById[familyId]; parentFamilyId != ""; parentFamilyId = parentFamilyById[parentFamilyId] {
+			path = append(path, parentFamilyId)
+			if len(path) > maxFamilyDepth {
+				slices.Reverse(path)
+				t.Fatalf("family hierarchy exceeds max depth of %d: %s", maxFamilyDepth, strings.Join(path, " -> "))
+				return
+			}
+		}
+	}
+}
+
+func TestReferences(t *testing.T) {
+	expectedFamilyIds := make(map[string]struct{})
+	expectedAircraftIds := make(map[string]struct{})
+
+	var err error
+	for _, row := range ReadCSV(strings.NewReader(aliases), &err) {
+		if aircraftId := row["aircraft_type"]; aircraftId != "" {
+			expectedAircraftIds[aircraftId] = struct{}{}
+		}
+
+		if familyId := row["aircraft_family"]; familyId != "" {
+			expectedFamilyIds[familyId] = struct{}{}
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, row := range ReadCSV(strings.NewReader(variants), &err) {
+		expectedAircraftIds[row["aircraft_type_id"]] = struct{}{}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, row := range ReadCSV(strings.NewReader(historicalAliases), &err) {
+		expectedAircraftIds[row["aircraft_type_id"]] = struct{}{}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var aircraftIds []string
+	for _, row := range ReadCSV(strings.NewReader(types), &err) {
+		if familyId := row["family_id"]; familyId != "" {
+			expectedFamilyIds[familyId] = struct{}{}
+		}
+
+		if supersededBy := row["superseded_by"]; supersededBy != "" {
+			expectedAircraftIds[supersededBy] = struct{}{}
+		}
+
+		aircraftIds = append(aircraftIds, row["id"])
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, aircraftId := range aircraftIds {
+		delete(expectedAircraftIds, aircraftId)
+	}
+
+	if len(expectedAircraftIds) > 0 {
+		t.Fatalf("missing expected aircraft ids: %v", expectedAircraftIds)
+		return
+	}
+
+	for _, row := range ReadCSV(strings.NewReader(families), &err) {
+		if familyId := row["parent_family"]; familyId != "" {
+			expectedFamilyIds[familyId] = struct{}{}
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, row := range ReadCSV(strings.NewReader(families), &err) {
+		delete(expectedFamilyIds, row["id"])
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(expectedFamilyIds) > 0 {
+		t.Fatalf("missing expected family ids: %v", expectedFamilyIds)
+		return
+	}
+}
+
+func TestNoDanglingFamilyReferences(t *testing.T) {
+	cases := []struct {
+		name     string
+		types    io.Reader
+		families io.Reader
+		expected int
+	}{
+		{name: "embedded", types: strings.NewReader(types), families: strings.NewReader(families)},
+		{
+			name:     "fixture",
+			types:    strings.NewReader("id,family_id,iata,icao,name\n320,32S,320,A320,Airbus A320\n321,XXX,321,A321,Airbus A321\n738,,738,B738,Boeing 737-800\n"),
+			families: strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,AIRBUS,family,Airbus A320\n737,737,,,family,Boeing 737\n"),
+			expected: 2,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			missing, err := danglingFamilyReferences(c.types, c.families)
+			if err != nil {
+				t.Fatal(err)
+				return
+			}
+
+			if len(missing) != c.expected {
+				for _, m := range missing {
+					t.Error(m)
+				}
+
+				t.Fatalf("expected %d dangling family references, got %d", c.expected, len(missing))
+				return
+			}
+		})
+	}
+}
+
+func TestFamilyHasAtLeastOneType(t *testing.T) {
+	cases := []struct {
+		name     string
+		types    io.Reader
+		families io.Reader
+		wantErr  bool
+	}{
+		{name: "embedded", types: strings.NewReader(types), families: strings.NewReader(families)},
+		{
+			name:     "sub family types",
+			types:    strings.NewReader("id,family_id,iata,icao,name\n738,737NG,738,B738,Boeing 737-800\n"),
+			families: strings.NewReader("id,iata,icao,parent_family,level,name\n737,737,,,family,Boeing 737\n737NG,,,737,sub_family,Boeing 737 NG\n"),
+		},
+		{
+			name:     "orphan",
+			types:    strings.NewReader("id,family_id,iata,icao,name\n738,737NG,738,B738,Boeing 737-800\n"),
+			families: strings.NewReader("id,iata,icao,parent_family,level,name\n737NG,,,,family,Boeing 737 NG\n32S,32S,,,family,Airbus A320\n"),
+			wantErr:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkFamiliesHaveTypes(c.types, c.families)
+			if c.wantErr && err == nil {
+				t.Fatal("expected family without aircraft types to be detected")
+				return
+			} else if !c.wantErr && err != nil {
+				t.Fatal(err)
+				return
+			}
+		})
+	}
+}
+
+func TestFilesAreSorted(t *testing.T) {
+	testFileIsSorted(t, strings.NewReader(aliases), "alias")
+	testFileIsSorted(t, strings.NewReader(families), "id")
+	testFileIsSorted(t, strings.NewReader(types), "id")
+	testFileIsSorted(t, strings.NewReader(variants), "id")
+	testFileIsSorted(t, strings.NewReader(historicalAliases), "historical_iata")
+	testFileIsSorted(t, strings.NewReader(countries), "code")
+	testFileIsSorted(t, strings.NewReader(performance), "aircraft_type_id")
+	testFileIsSorted(t, strings.NewReader(photos), "aircraft_type_id", "url")
+	testFileIsSorted(t, strings.NewReader(deprecationLog), "old_iata", "new_iata")
+	testFileIsSorted(t, strings.NewReader(regulatory), "aircraft_type_id", "authority")
+	testFileIsSorted(t, strings.NewReader(seatConfigurations), "aircraft_type_id", "config_name")
+}
+
+func TestNoLeadingTrailingWhitespace(t *testing.T) {
+	testNoLeadingTrailingWhitespace(t, strings.NewReader(aliases), "alias")
+	testNoLeadingTrailingWhitespace(t, strings.NewReader(families), "iata", "icao", "manufacturer_region", "commercial_name", "name")
+	testNoLeadingTrailingWhitespace(t, strings.NewReader(types), "iata", "icao", "name")
+	testNoLeadingTrailingWhitespace(t, strings.NewReader(variants), "icao", "name")
+	testNoLeadingTrailingWhitespace(t, strings.NewReader(countries), "code", "name", "region")
+	testNoLeadingTrailingWhitespace(t, strings.NewReader(seatConfigurations), "config_name")
+	testNoLeadingTrailingWhitespace(t, strings.NewReader(photos), "url", "attribution", "license")
+	testNoLeadingTrailingWhitespace(t, strings.NewReader(deprecationLog), "reason")
+	testNoLeadingTrailingWhitespace(t, strings.NewReader(regulatory), "certificate_number")
+}
+
+func BenchmarkReadCSV(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		var err error
+		for range ReadCSV(strings.NewReader(types), &err) {
+		}
+
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadCSVTyped(b *testing.B) {
+	type row struct {
+		id, name string
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		var err error
+		parse := func(headers, record []string) (row, error) {
+			return row{id: record[0], name: record[len(record)-1]}, nil
+		}
+
+		for range readCsvTyped(strings.NewReader(types), parse, &err) {
+		}
+
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func testIdsAreUnique(t *testing.T, readersAndIdColumns ...readerAndIdColumn) {
+	var err error
+	ids := make(map[string]struct{})
+	for _, readerAndIdColumn := range readersAndIdColumns {
+		for line, row := range ReadCSV(readerAndIdColumn.reader, &err) {
+			id := row[readerAndIdColumn.idColumn]
+			if id == "" {
+				if !readerAndIdColumn.allowNull {
+					t.Fatalf("%s is null in line %d", readerAndIdColumn.idColumn, line)
+				}
+			} else {
+				if _, ok := ids[id]; ok {
+					t.Fatalf("duplicate %s: %q in line %d", readerAndIdColumn.idColumn, id, line)
+					return
+				}
+
+				ids[id] = struct{}{}
+			}
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+// testFileIsSorted fails the test unless the rows of reader are strictly sorted by the values of columns,
+// comparing the columns in order.
+func testFileIsSorted(t *testing.T, reader io.Reader, columns ...string) {
+	var err error
+	var prev []string
+	for line, row := range ReadCSV(reader, &err) {
+		key := make([]string, len(columns))
+		for i, column := range columns {
+			key[i] = row[column]
+		}
+
+		if line > 1 && slices.Compare(key, prev) <= 0 {
+			t.Fatalf("%s %q in line %d is not sorted after %q", strings.Join(columns, ","), key, line, prev)
+			return
+		}
+
+		prev = key
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+func testNoLeadingTrailingWhitespace(t *testing.T, reader io.Reader, columns ...string) {
+	var err error
+	for line, row := range ReadCSV(reader, &err) {
+		for _, column := range columns {
+			if v := row[column]; strings.TrimSpace(v) != v {
+				t.Fatalf("%s %q in line %d has leading or trailing whitespace", column, v, line)
+				return
+			}
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+}
+
+// checkSeatConfigurations checks that seat counts are non-negative, that every configuration references an existing
+// aircraft type, that configuration names are unique per type and that no configuration has more seats than the typical
+// seats of its type, if known.
+func checkSeatConfigurations(configs, aircraftTypes io.Reader) error {
+	var err error
+	typicalSeatsById := make(map[string]string)
+	for _, row := range ReadCSV(aircraftTypes, &err) {
+		typicalSeatsById[row["id"]] = row["typical_seats"]
+	}
+
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[[2]string]struct{})
+	for line, row := range ReadCSV(configs, &err) {
+		typicalSeats, ok := typicalSeatsById[row["aircraft_type_id"]]
+		if !ok {
+			return fmt.Errorf("unknown aircraft type %q in line %d", row["aircraft_type_id"], line)
+		}
+
+		key := [2]string{row["aircraft_type_id"], row["config_name"]}
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("duplicate configuration %q of aircraft type %q in line %d", key[1], key[0], line)
+		}
+		seen[key] = struct{}{}
+
+		var total int
+		for _, column := range []string{"first_seats", "business_seats", "premium_economy_seats", "economy_seats"} {
+			n, err := strconv.Atoi(row[column])
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid %s %q in line %d", column, row[column], line)
+			}
+
+			total += n
+		}
+
+		if typicalSeats != "" {
+			if n, err := strconv.Atoi(typicalSeats); err == nil && total > n {
+				return fmt.Errorf("configuration %q of aircraft type %q has %d seats, more than the typical %d in line %d", key[1], key[0], total, n, line)
+			}
+		}
+	}
+
+	return err
+}
+
+// checkDeprecationLog checks that both codes of every entry are 3 character codes, that the change year is
+// a plausible year and that the aircraft type exists or is marked external.
+func checkDeprecationLog(deprecationLog, aircraftTypes io.Reader) error {
+	var err error
+	aircraftIds := map[string]struct{}{ExternalAircraftTypeId: {}}
+	for _, row := range ReadCSV(aircraftTypes, &err) {
+		aircraftIds[row["id"]] = struct{}{}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for line, row := range ReadCSV(deprecationLog, &err) {
+		for _, column := range []string{"old_iata", "new_iata"} {
+			if _, err := NewIATA(row[column]); err != nil {
+				return fmt.Errorf("invalid %s %q in line %d", column, row[column], line)
+			}
+		}
+
+		if n, err := strconv.Atoi(row["change_year"]); err != nil || n < 1900 || n > 2100 {
+			return fmt.Errorf("invalid change_year %q in line %d", row["change_year"], line)
+		}
+
+		if _, ok := aircraftIds[row["aircraft_type_id"]]; !ok {
+			return fmt.Errorf("unknown aircraft type %q in line %d", row["aircraft_type_id"], line)
+		}
+	}
+
+	return err
+}
+
+// issueYearPattern matches the 4 digit issue years of aircraft_regulatory.csv.
+var issueYearPattern = regexp.MustCompile(`^[0-9]{4}$`)
+
+// checkRegulatory checks that every certificate covers an existing aircraft type, was issued by one of the
+// regulatoryAuthorities and has a 4 digit issue year.
+func checkRegulatory(regulatoryEntries, aircraftTypes io.Reader) error {
+	var err error
+	aircraftIds := make(map[string]struct{})
+	for _, row := range ReadCSV(aircraftTypes, &err) {
+		aircraftIds[row["id"]] = struct{}{}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for line, row := range ReadCSV(regulatoryEntries, &err) {
+		if _, ok := aircraftIds[row["aircraft_type_id"]]; !ok {
+			return fmt.Errorf("unknown aircraft type %q in line %d", row["aircraft_type_id"], line)
+		}
+
+		if !slices.Contains(regulatoryAuthorities, RegulatoryAuthority(row["authority"])) {
+			return fmt.Errorf("invalid authority %q in line %d", row["authority"], line)
+		}
+
+		if !issueYearPattern.MatchString(row["issue_year"]) {
+			return fmt.Errorf("invalid issue_year %q in line %d", row["issue_year"], line)
+		}
+	}
+
+	return err
+}
+
+// maxServiceCeilingFt is the exclusive upper bound of plausible service ceilings.
+const maxServiceCeilingFt = 60000
+
+// checkPerformance checks that all values are positive integers, that service ceilings are below maxServiceCeilingFt
+// and that every record references an existing aircraft type.
+func checkPerformance(performance, aircraftTypes io.Reader) error {
+	var err error
+	aircraftIds := make(map[string]struct{})
+	for _, row := range ReadCSV(aircraftTypes, &err) {
+		aircraftIds[row["id"]] = struct{}{}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for line, row := range ReadCSV(performance, &err) {
+		if _, ok := aircraftIds[row["aircraft_type_id"]]; !ok {
+			return fmt.Errorf("unknown aircraft type %q in line %d", row["aircraft_type_id"], line)
+		}
+
+		for _, column := range []string{"cruise_speed_kmh", "service_ceiling_ft", "takeoff_distance_m", "landing_distance_m"} {
+			if n, err := strconv.Atoi(row[column]); err != nil || n < 1 {
+				return fmt.Errorf("invalid %s %q in line %d", column, row[column], line)
+			}
+		}
+
+		if n, _ := strconv.Atoi(row["service_ceiling_ft"]); n >= maxServiceCeilingFt {
+			return fmt.Errorf("service_ceiling_ft %d in line %d is not below %d", n, line, maxServiceCeilingFt)
+		}
+	}
+
+	return err
+}
+
+// checkPhotos checks that every photo references an existing aircraft type, has an absolute http or https url,
+// a non-blank attribution and one of photoLicenses.
+func checkPhotos(photos, aircraftTypes io.Reader) error {
+	var err error
+	aircraftIds := make(map[string]struct{})
+	for _, row := range ReadCSV(aircraftTypes, &err) {
+		aircraftIds[row["id"]] = struct{}{}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for line, row := range ReadCSV(photos, &err) {
+		if _, ok := aircraftIds[row["aircraft_type_id"]]; !ok {
+			return fmt.Errorf("unknown aircraft type %q in line %d", row["aircraft_type_id"], line)
+		}
+
+		if u, err := url.Parse(row["url"]); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid url %q in line %d", row["url"], line)
+		}
+
+		if strings.TrimSpace(row["attribution"]) == "" {
+			return fmt.Errorf("blank attribution in line %d", line)
+		}
+
+		if !slices.Contains(photoLicenses, row["license"]) {
+			return fmt.Errorf("unknown license %q in line %d", row["license"], line)
+		}
+	}
+
+	return err
+}
+
+// danglingFamilyReferences returns a description of every family_id of aircraftTypes and every parent_family
+// of aircraftFamilies which is not the id of a family in aircraftFamilies.
+func danglingFamilyReferences(aircraftTypes, aircraftFamilies io.Reader) ([]string, error) {
+	var err error
+	familyRows := make(map[int]map[string]string)
+	familyIds := make(map[string]struct{})
+	for line, row := range ReadCSV(aircraftFamilies, &err) {
+		familyRows[line] = row
+		familyIds[row["id"]] = struct{}{}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for line, row := range ReadCSV(aircraftTypes, &err) {
+		if _, ok := familyIds[row["family_id"]]; row["family_id"] != "" && !ok {
+			missing = append(missing, fmt.Sprintf("aircraft_types.csv line %d: aircraft type %q references missing family %q", line, row["id"], row["family_id"]))
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range slices.Sorted(maps.Keys(familyRows)) {
+		row := familyRows[line]
+		if _, ok := familyIds[row["parent_family"]]; row["parent_family"] != "" && !ok {
+			missing = append(missing, fmt.Sprintf("aircraft_families.csv line %d: family %q references missing parent family %q", line, row["id"], row["parent_family"]))
+		}
+	}
+
+	return missing, nil
+}
+
+// checkFamiliesHaveTypes checks that every family except familiesWithoutTypes has at least one aircraft type
+// assigned to itself or to one of its sub families.
+func checkFamiliesHaveTypes(aircraftTypes, aircraftFamilies io.Reader) error {
+	var err error
+	parentById := make(map[string]string)
+	var familyRows []map[string]string
+	for _, row := range ReadCSV(aircraftFamilies, &err) {
+		parentById[row["id"]] = row["parent_family"]
+		familyRows = append(familyRows, row)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	withTypes := make(map[string]struct{})
+	for _, row := range ReadCSV(aircraftTypes, &err) {
+		for familyId := row["family_id"]; familyId != ""; familyId = parentById[familyId] {
+			if _, ok := withTypes[familyId]; ok {
+				break
+			}
+
+			withTypes[familyId] = struct{}{}
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, row := range familyRows {
+		if _, ok := withTypes[row["id"]]; !ok && !slices.Contains(familiesWithoutTypes, row["id"]) {
+			return fmt.Errorf("family %q (%s) has no aircraft types", row["id"], row["name"])
+		}
+	}
+
+	return nil
+}
+
+func checkNoSelfReferencingFamily(reader io.Reader) error {
+	var err error
+	for line, row := range ReadCSV(reader, &err) {
+		if row["parent_family"] == row["id"] {
+			return fmt.Errorf("family %q references itself as parent in line %d", row["id"], line)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,82 @@
+package referencedata
+
+import (
+	"slices"
+	"strings"
+)
+
+// TableDiff lists the rows of a table which differ between two builds of the reference data.
+type TableDiff struct {
+	Table   string      `json:"table"`
+	Added   []string    `json:"added"`
+	Removed []string    `json:"removed"`
+	Changed []RowChange `json:"changed"`
+}
+
+// RowChange lists the fields of a row, identified by its primary key, which differ between two builds.
+type RowChange struct {
+	Key    string        `json:"key"`
+	Fields []FieldChange `json:"fields"`
+}
+
+// FieldChange is a single field which differs between two builds.
+type FieldChange struct {
+	Field string `json:"field"`
+	Base  string `json:"base"`
+	Head  string `json:"head"`
+}
+
+// DiffRegistries compares the tables of base and head by primary key.
+func DiffRegistries(base, head *Registry) []TableDiff {
+	return []TableDiff{
+		diffTable("aircraft_types", aircraftTypesSchema, base.Types(), head.Types(), aircraftTypeFields),
+		diffTable("aircraft_families", aircraftFamiliesSchema, base.Families(), head.Families(), aircraftFamilyFields),
+		diffTable("aircraft_aliases", aircraftAliasesSchema, base.Aliases(), head.Aliases(), aircraftAliasFields),
+	}
+}
+
+// diffTable compares rows by their first field, which is the primary key. fields returns the values of a row in the order of columns.
+func diffTable[T any](table string, columns []string, base, head []T, fields func(T) []string) TableDiff {
+	diff := TableDiff{Table: table}
+
+	baseByKey := make(map[string][]string, len(base))
+	for _, row := range base {
+		values := fields(row)
+		baseByKey[values[0]] = values
+	}
+
+	headByKey := make(map[string][]string, len(head))
+	for _, row := range head {
+		values := fields(row)
+		headByKey[values[0]] = values
+
+		baseValues, ok := baseByKey[values[0]]
+		if !ok {
+			diff.Added = append(diff.Added, values[0])
+			continue
+		}
+
+		var changes []FieldChange
+		for i, column := range columns {
+			if baseValues[i] != values[i] {
+				changes = append(changes, FieldChange{Field: column, Base: baseValues[i], Head: values[i]})
+			}
+		}
+
+		if len(changes) > 0 {
+			diff.Changed = append(diff.Changed, RowChange{Key: values[0], Fields: changes})
+		}
+	}
+
+	for key := range baseByKey {
+		if _, ok := headByKey[key]; !ok {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+
+	slices.Sort(diff.Added)
+	slices.Sort(diff.Removed)
+	slices.SortFunc(diff.Changed, func(a, b RowChange) int { return strings.Compare(a.Key, b.Key) })
+
+	return diff
+}
@@ -0,0 +1,47 @@
+package referencedata
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiffRegistries(t *testing.T) {
+	base, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n100,,100,F100,Fokker 100\n320,,320,A320,Airbus A320\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32A,320,\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	head, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320ceo\n321,,321,A321,Airbus A321\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32A,320,\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	diffs := DiffRegistries(base, head)
+	types := diffs[0]
+	if !slices.Equal(types.Added, []string{"321"}) || !slices.Equal(types.Removed, []string{"100"}) {
+		t.Fatalf("unexpected added or removed types: %+v", types)
+		return
+	}
+
+	expected := []RowChange{{Key: "320", Fields: []FieldChange{{Field: "name", Base: "Airbus A320", Head: "Airbus A320ceo"}}}}
+	if len(types.Changed) != 1 || types.Changed[0].Key != expected[0].Key || !slices.Equal(types.Changed[0].Fields, expected[0].Fields) {
+		t.Fatalf("expected %+v, got %+v", expected, types.Changed)
+		return
+	}
+
+	if aliases := diffs[2]; len(aliases.Added)+len(aliases.Removed)+len(aliases.Changed) != 0 {
+		t.Fatalf("expected no alias changes, got %+v", aliases)
+		return
+	}
+}
@@ -0,0 +1,20 @@
+package referencedata
+
+// Minimum ranges in km of the ETOPS ratings assumed by AircraftType.ETOPS, a twin jet with a range of at least the
+// value is given the rating.
+// Jet twins below etops120MinRangeKm are rated 60 minutes, those with an unknown range are not rated.
+const (
+	etops120MinRangeKm = 3000
+	etops180MinRangeKm = 6000
+	etops240MinRangeKm = 9000
+	etops370MinRangeKm = 12000
+)
+
+// ETOPS ratings in minutes returned by AircraftType.ETOPS.
+const (
+	etops60  = 60
+	etops120 = 120
+	etops180 = 180
+	etops240 = 240
+	etops370 = 370
+)
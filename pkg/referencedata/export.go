@@ -0,0 +1,510 @@
+package referencedata
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// denormalizedHeader is the header row written by ExportDenormalized.
+var denormalizedHeader = []string{
+	"alias",
+	"aircraft_type_id",
+	"aircraft_type_name",
+	"aircraft_type_iata",
+	"aircraft_type_icao",
+	"family_id",
+	"family_name",
+	"family_iata",
+}
+
+// ExportDenormalized writes one csv row per alias with the aircraft type and family it resolves to.
+// For aliases of an aircraft type the family columns contain the family of that type,
+// for aliases of a family the aircraft type columns are empty.
+func ExportDenormalized(w io.Writer, reg *Registry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(denormalizedHeader); err != nil {
+		return err
+	}
+
+	for _, a := range reg.Aliases() {
+		var t *AircraftType
+		var f *AircraftFamily
+		if a.AircraftTypeId != "" {
+			t, _ = reg.Type(a.AircraftTypeId)
+			if t != nil {
+				f, _ = reg.Family(t.FamilyId)
+			}
+		} else {
+			f, _ = reg.Family(a.AircraftFamilyId)
+		}
+
+		record := []string{string(a.Alias), a.AircraftTypeId, "", "", "", "", "", ""}
+		if t != nil {
+			record[2], record[3], record[4] = t.Name, string(t.IATA), string(t.ICAO)
+		}
+
+		if f != nil {
+			record[5], record[6], record[7] = f.Id, f.Name, string(f.IATA)
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportNeo4jCypher writes cypher statements which merge all aircraft types, families and aliases
+// and the relationships between them into a Neo4j database. Each statement is on its own line.
+// Relationships referencing a missing record are skipped.
+func ExportNeo4jCypher(w io.Writer, reg *Registry) error {
+	for _, t := range reg.Types() {
+		if _, err := fmt.Fprintf(w, "MERGE (t:AircraftType {id: %s}) SET t.name = %s, t.iata = %s, t.icao = %s;\n", cypherString(t.Id), cypherString(t.Name), cypherString(string(t.IATA)), cypherString(string(t.ICAO))); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range reg.Families() {
+		if _, err := fmt.Fprintf(w, "MERGE (f:AircraftFamily {id: %s}) SET f.name = %s, f.iata = %s, f.level = %s;\n", cypherString(f.Id), cypherString(f.Name), cypherString(string(f.IATA)), cypherString(f.Level)); err != nil {
+			return err
+		}
+	}
+
+	for _, a := range reg.Aliases() {
+		if _, err := fmt.Fprintf(w, "MERGE (a:AircraftAlias {alias: %s});\n", cypherString(string(a.Alias))); err != nil {
+			return err
+		}
+	}
+
+	for _, a := range reg.Aliases() {
+		var err error
+		if _, ok := reg.Type(a.AircraftTypeId); ok {
+			_, err = fmt.Fprintf(w, "MATCH (a:AircraftAlias {alias: %s}), (b:AircraftType {id: %s}) MERGE (a)-[:ALIAS_FOR]->(b);\n", cypherString(string(a.Alias)), cypherString(a.AircraftTypeId))
+		} else if _, ok := reg.Family(a.AircraftFamilyId); ok {
+			_, err = fmt.Fprintf(w, "MATCH (a:AircraftAlias {alias: %s}), (b:AircraftFamily {id: %s}) MERGE (a)-[:ALIAS_FOR]->(b);\n", cypherString(string(a.Alias)), cypherString(a.AircraftFamilyId))
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, t := range reg.Types() {
+		if _, ok := reg.Family(t.FamilyId); !ok {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "MATCH (f:AircraftFamily {id: %s}), (t:AircraftType {id: %s}) MERGE (f)-[:HAS_TYPE]->(t);\n", cypherString(t.FamilyId), cypherString(t.Id)); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range reg.Families() {
+		if _, ok := reg.Family(f.ParentFamilyId); !ok {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "MATCH (p:AircraftFamily {id: %s}), (f:AircraftFamily {id: %s}) MERGE (p)-[:HAS_FAMILY]->(f);\n", cypherString(f.ParentFamilyId), cypherString(f.Id)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// graphML is the root element of a GraphML document.
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	Id       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	Id          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	Id   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ExportGraphML writes a GraphML document with a node for every aircraft type, family and alias
+// and a directed edge for every relationship between them, for import into tools like Gephi or yEd.
+// Nodes carry a label and a kind, edges carry the kind of the relationship.
+// Relationships referencing a missing record are skipped.
+func ExportGraphML(w io.Writer, reg *Registry) error {
+	doc := graphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{Id: "label", For: "node", AttrName: "label", AttrType: "string"},
+			{Id: "kind", For: "node", AttrName: "kind", AttrType: "string"},
+			{Id: "relation", For: "edge", AttrName: "relation", AttrType: "string"},
+		},
+		Graph: graphMLGraph{Id: "reference-data", EdgeDefault: "directed"},
+	}
+
+	addNode := func(id, label, kind string) {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			Id:   id,
+			Data: []graphMLData{{Key: "label", Value: label}, {Key: "kind", Value: kind}},
+		})
+	}
+
+	addEdge := func(source, target, relation string) {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: source,
+			Target: target,
+			Data:   []graphMLData{{Key: "relation", Value: relation}},
+		})
+	}
+
+	for _, t := range reg.Types() {
+		addNode(typeNodeId(t.Id), t.Name, "aircraft_type")
+	}
+
+	for _, f := range reg.Families() {
+		addNode(familyNodeId(f.Id), f.CommercialName(), "aircraft_family")
+	}
+
+	for _, a := range reg.Aliases() {
+		addNode(aliasNodeId(a.Alias), string(a.Alias), "alias")
+	}
+
+	for _, e := range registryEdges(reg) {
+		addEdge(e.source, e.target, e.relation)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ExportAdjacencyList returns the directed graph of all aircraft types, families and aliases as adjacency list.
+// Keys are node ids prefixed with type:, family: or alias:, values are the ids of the nodes an edge points to.
+// Every node is a key, nodes without outgoing edges have an empty slice.
+func ExportAdjacencyList(reg *Registry) map[string][]string {
+	adjacency := make(map[string][]string, len(reg.Types())+len(reg.Families())+len(reg.Aliases()))
+	for _, t := range reg.Types() {
+		adjacency[typeNodeId(t.Id)] = []string{}
+	}
+
+	for _, f := range reg.Families() {
+		adjacency[familyNodeId(f.Id)] = []string{}
+	}
+
+	for _, a := range reg.Aliases() {
+		adjacency[aliasNodeId(a.Alias)] = []string{}
+	}
+
+	for _, e := range registryEdges(reg) {
+		adjacency[e.source] = append(adjacency[e.source], e.target)
+	}
+
+	return adjacency
+}
+
+// registryEdge is a directed relationship between two nodes of the exported graphs.
+type registryEdge struct {
+	source, target, relation string
+}
+
+// registryEdges returns the relationships between aircraft types, families and aliases:
+// aliases point to their target, families to their aircraft types and sub families.
+// Relationships referencing a missing record are skipped.
+func registryEdges(reg *Registry) []registryEdge {
+	var edges []registryEdge
+	for _, a := range reg.Aliases() {
+		if _, ok := reg.Type(a.AircraftTypeId); ok {
+			edges = append(edges, registryEdge{aliasNodeId(a.Alias), typeNodeId(a.AircraftTypeId), "alias_for"})
+		} else if _, ok := reg.Family(a.AircraftFamilyId); ok {
+			edges = append(edges, registryEdge{aliasNodeId(a.Alias), familyNodeId(a.AircraftFamilyId), "alias_for"})
+		}
+	}
+
+	for _, t := range reg.Types() {
+		if _, ok := reg.Family(t.FamilyId); ok {
+			edges = append(edges, registryEdge{familyNodeId(t.FamilyId), typeNodeId(t.Id), "has_type"})
+		}
+	}
+
+	for _, f := range reg.Families() {
+		if _, ok := reg.Family(f.ParentFamilyId); ok {
+			edges = append(edges, registryEdge{familyNodeId(f.ParentFamilyId), familyNodeId(f.Id), "has_family"})
+		}
+	}
+
+	return edges
+}
+
+func typeNodeId(id string) string {
+	return "type:" + id
+}
+
+func familyNodeId(id string) string {
+	return "family:" + id
+}
+
+func aliasNodeId(alias IATA) string {
+	return "alias:" + string(alias)
+}
+
+// turtleNamespace is the namespace of the classes, properties and resources written by ExportRDFTurtle.
+const turtleNamespace = "https://explore-flights.example/aircraft#"
+
+// turtleLocalNamePattern matches ids which can be written as prefixed names without escaping.
+var turtleLocalNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// turtleEscaper escapes the characters which are not allowed unescaped in a Turtle string literal.
+var turtleEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// ExportRDFTurtle writes all aircraft types, families and aliases as Turtle RDF.
+// Aircraft types and families link to their family with ef:belongsToFamily, aliases link to their target with ef:isAliasFor.
+// Relationships referencing a missing record are skipped.
+func ExportRDFTurtle(w io.Writer, reg *Registry) error {
+	var sb strings.Builder
+	sb.WriteString("@prefix ef: <" + turtleNamespace + "> .\n")
+
+	for _, t := range reg.Types() {
+		sb.WriteString("\n" + turtleResource("type", t.Id) + " a ef:AircraftType ;\n")
+		sb.WriteString("  ef:iata " + turtleString(string(t.IATA)) + " ;\n")
+		if t.ICAO != "" {
+			sb.WriteString("  ef:icao " + turtleString(string(t.ICAO)) + " ;\n")
+		}
+
+		if _, ok := reg.Family(t.FamilyId); ok {
+			sb.WriteString("  ef:belongsToFamily " + turtleResource("family", t.FamilyId) + " ;\n")
+		}
+
+		sb.WriteString("  ef:name " + turtleString(t.Name) + " .\n")
+	}
+
+	for _, f := range reg.Families() {
+		sb.WriteString("\n" + turtleResource("family", f.Id) + " a ef:AircraftFamily ;\n")
+		if f.IATA != "" {
+			sb.WriteString("  ef:iata " + turtleString(string(f.IATA)) + " ;\n")
+		}
+
+		if f.ICAO != "" {
+			sb.WriteString("  ef:icao " + turtleString(string(f.ICAO)) + " ;\n")
+		}
+
+		if _, ok := reg.Family(f.ParentFamilyId); ok {
+			sb.WriteString("  ef:belongsToFamily " + turtleResource("family", f.ParentFamilyId) + " ;\n")
+		}
+
+		sb.WriteString("  ef:level " + turtleString(f.Level) + " ;\n")
+		sb.WriteString("  ef:name " + turtleString(f.Name) + " .\n")
+	}
+
+	for _, a := range reg.Aliases() {
+		sb.WriteString("\n" + turtleResource("alias", string(a.Alias)) + " a ef:AircraftAlias ;\n")
+		if _, ok := reg.Type(a.AircraftTypeId); ok {
+			sb.WriteString("  ef:isAliasFor " + turtleResource("type", a.AircraftTypeId) + " ;\n")
+		} else if _, ok := reg.Family(a.AircraftFamilyId); ok {
+			sb.WriteString("  ef:isAliasFor " + turtleResource("family", a.AircraftFamilyId) + " ;\n")
+		}
+
+		sb.WriteString("  ef:iata " + turtleString(string(a.Alias)) + " .\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// turtleResource returns the Turtle term of the resource of the given kind and id,
+// falling back to a full IRI if the id cannot be written as prefixed name.
+func turtleResource(kind, id string) string {
+	if turtleLocalNamePattern.MatchString(id) {
+		return "ef:" + kind + "_" + id
+	}
+
+	return "<" + turtleNamespace + kind + "_" + url.PathEscape(id) + ">"
+}
+
+// turtleString quotes s as a Turtle string literal.
+func turtleString(s string) string {
+	return `"` + turtleEscaper.Replace(s) + `"`
+}
+
+// markdownEscaper escapes pipes and replaces line breaks, which would end a cell or row of a Markdown table.
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+// ExportMarkdownTable writes all aircraft types sorted by IATA code as a GitHub flavored Markdown table.
+// The family column contains the name of the family of the aircraft type.
+func ExportMarkdownTable(w io.Writer, reg *Registry) error {
+	var sb strings.Builder
+	sb.WriteString("| IATA | ICAO | Name | Family | Body Type |\n")
+	sb.WriteString("|---|---|---|---|---|\n")
+
+	aircraftTypes := slices.SortedStableFunc(slices.Values(reg.Types()), func(a, b *AircraftType) int {
+		return strings.Compare(string(a.IATA), string(b.IATA))
+	})
+
+	for _, t := range aircraftTypes {
+		var familyName string
+		if f, ok := reg.Family(t.FamilyId); ok {
+			familyName = f.Name
+		}
+
+		sb.WriteString("|")
+		for _, value := range []string{string(t.IATA), string(t.ICAO), t.Name, familyName, string(t.BodyType)} {
+			sb.WriteString(" " + markdownEscaper.Replace(value) + " |")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// dotClusterKeyPattern matches the characters which can't be part of an unquoted cluster name in DOT.
+var dotClusterKeyPattern = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// dotEscaper escapes the characters which are not allowed unescaped in a quoted DOT string.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dotCluster is a subgraph cluster of aircraft types written by WriteGraphvizClusterDOT.
+type dotCluster struct {
+	key, label string
+	types      []*AircraftType
+}
+
+// WriteGraphvizClusterDOT writes all aircraft types, families and aliases and the relationships between them as DOT
+// graph. Aircraft types are grouped into a subgraph cluster_<key> per value of clusterBy, which is one of
+// manufacturer, body_type, family or none. Clusters are in the order of their first aircraft type,
+// aircraft types without a value are written outside of all clusters.
+func WriteGraphvizClusterDOT(w io.Writer, reg *Registry, clusterBy string) error {
+	var clusterOf func(t *AircraftType) (key, label string, ok bool)
+	switch clusterBy {
+	case "manufacturer":
+		clusterOf = func(t *AircraftType) (string, string, bool) {
+			if f, ok := reg.Manufacturer(t); ok {
+				return f.Id, f.CommercialName(), true
+			}
+
+			return "", "", false
+		}
+	case "body_type":
+		clusterOf = func(t *AircraftType) (string, string, bool) {
+			return string(t.BodyType), string(t.BodyType), t.BodyType != ""
+		}
+	case "family":
+		clusterOf = func(t *AircraftType) (string, string, bool) {
+			if f, ok := reg.Family(t.FamilyId); ok {
+				return f.Id, f.CommercialName(), true
+			}
+
+			return "", "", false
+		}
+	case "none":
+		clusterOf = func(t *AircraftType) (string, string, bool) {
+			return "", "", false
+		}
+	default:
+		return fmt.Errorf("unsupported cluster key %q", clusterBy)
+	}
+
+	var clusters []*dotCluster
+	clusterByKey := make(map[string]*dotCluster)
+	var unclustered []*AircraftType
+	for _, t := range reg.Types() {
+		key, label, ok := clusterOf(t)
+		if !ok {
+			unclustered = append(unclustered, t)
+			continue
+		}
+
+		c, ok := clusterByKey[key]
+		if !ok {
+			c = &dotCluster{key: key, label: label}
+			clusters = append(clusters, c)
+			clusterByKey[key] = c
+		}
+
+		c.types = append(c.types, t)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph aircraft {\n")
+	for _, c := range clusters {
+		sb.WriteString("  subgraph cluster_" + dotClusterKeyPattern.ReplaceAllString(c.key, "_") + " {\n")
+		sb.WriteString("    label=" + dotString(c.label) + ";\n")
+		for _, t := range c.types {
+			sb.WriteString("    " + dotString(typeNodeId(t.Id)) + " [label=" + dotString(t.Name) + "];\n")
+		}
+
+		sb.WriteString("  }\n")
+	}
+
+	for _, t := range unclustered {
+		sb.WriteString("  " + dotString(typeNodeId(t.Id)) + " [label=" + dotString(t.Name) + "];\n")
+	}
+
+	for _, f := range reg.Families() {
+		sb.WriteString("  " + dotString(familyNodeId(f.Id)) + " [label=" + dotString(f.CommercialName()) + "];\n")
+	}
+
+	for _, a := range reg.Aliases() {
+		sb.WriteString("  " + dotString(aliasNodeId(a.Alias)) + " [label=" + dotString(string(a.Alias)) + "];\n")
+	}
+
+	for _, e := range registryEdges(reg) {
+		sb.WriteString("  " + dotString(e.source) + " -> " + dotString(e.target) + ";\n")
+	}
+
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// dotString quotes s as a DOT string.
+func dotString(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+// cypherString quotes s as a cypher string literal.
+func cypherString(s string) string {
+	return strconv.Quote(s)
+}
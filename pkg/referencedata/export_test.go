@@ -0,0 +1,581 @@
+package referencedata
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"github.com/goccy/go-graphviz"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExportDenormalized(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ExportDenormalized(&buf, reg); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(records) != len(reg.Aliases())+1 {
+		t.Fatalf("expected %d rows, got %d", len(reg.Aliases())+1, len(records))
+		return
+	}
+
+	if !slices.Equal(records[0], denormalizedHeader) {
+		t.Fatalf("unexpected header: %v", records[0])
+		return
+	}
+
+	for i, a := range reg.Aliases() {
+		record := records[i+1]
+		if record[0] != string(a.Alias) {
+			t.Fatalf("expected alias %s in row %d, got %s", a.Alias, i+1, record[0])
+			return
+		}
+
+		if at, ok := reg.Type(a.AircraftTypeId); ok && record[2] != at.Name {
+			t.Fatalf("expected aircraft type name %q for alias %s, got %q", at.Name, a.Alias, record[2])
+			return
+		}
+	}
+}
+
+func TestExportNeo4jCypher(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n320,32S,320,A320,Airbus A320\n321,32S,321,A321,Airbus A321\n100,,100,F100,Fokker 100\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,AIRBUS,family,Airbus A320\nAIRBUS,,,,manufacturer,Airbus\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32A,320,\n32C,,32S\n32X,XXX,\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ExportNeo4jCypher(&buf, reg); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	// 3 types, 2 families and 3 aliases; 2 alias edges, 2 type edges and 1 family edge
+	if expected := 3 + 2 + 3 + 2 + 2 + 1; len(lines) != expected {
+		t.Fatalf("expected %d statements, got %d:\n%s", expected, len(lines), buf.String())
+		return
+	}
+
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "MERGE ") && !strings.HasPrefix(line, "MATCH ") || !strings.HasSuffix(line, ";") || strings.Contains(line, "CREATE") {
+			t.Fatalf("unexpected statement: %s", line)
+			return
+		}
+	}
+}
+
+func TestExportGraphML(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ExportGraphML(&buf, reg); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var doc struct {
+		Nodes []struct {
+			Id   string `xml:"id,attr"`
+			Data []struct {
+				Key   string `xml:"key,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"data"`
+		} `xml:"graph>node"`
+		Edges []struct {
+			Source string `xml:"source,attr"`
+			Target string `xml:"target,attr"`
+		} `xml:"graph>edge"`
+	}
+
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if expected := len(reg.Types()) + len(reg.Families()) + len(reg.Aliases()); len(doc.Nodes) != expected {
+		t.Fatalf("expected %d nodes, got %d", expected, len(doc.Nodes))
+		return
+	}
+
+	expectedEdges := csvEdgeCount(t)
+	if len(doc.Edges) != expectedEdges {
+		t.Fatalf("expected %d edges, got %d", expectedEdges, len(doc.Edges))
+		return
+	}
+
+	nodeIds := make(map[string]struct{}, len(doc.Nodes))
+	labels := make(map[string]string, len(doc.Nodes))
+	for _, node := range doc.Nodes {
+		nodeIds[node.Id] = struct{}{}
+
+		keys := make(map[string]string)
+		for _, d := range node.Data {
+			keys[d.Key] = d.Value
+		}
+
+		if keys["label"] == "" || keys["kind"] == "" {
+			t.Fatalf("expected label and kind for node %s, got %v", node.Id, keys)
+			return
+		}
+
+		labels[node.Id] = keys["label"]
+	}
+
+	for _, f := range reg.Families() {
+		if label := labels[familyNodeId(f.Id)]; label != f.CommercialName() {
+			t.Fatalf("expected label %q for family %s, got %q", f.CommercialName(), f.Id, label)
+			return
+		}
+	}
+
+	for _, edge := range doc.Edges {
+		_, sourceOk := nodeIds[edge.Source]
+		_, targetOk := nodeIds[edge.Target]
+		if !sourceOk || !targetOk {
+			t.Fatalf("edge %s -> %s references a missing node", edge.Source, edge.Target)
+			return
+		}
+	}
+}
+
+func TestExportGraphMLFamilyCommercialName(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n320,32S,320,A320,Airbus A320\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,commercial_name,name\n32S,32S,,,family,A320neo Family,Airbus A320 Family\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ExportGraphML(&buf, reg); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if !strings.Contains(buf.String(), "<data key=\"label\">A320neo Family</data>") {
+		t.Fatalf("expected the commercial name as family label, got %s", buf.String())
+		return
+	}
+}
+
+func TestExportAdjacencyList(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	adjacency := ExportAdjacencyList(reg)
+	if expected := len(reg.Types()) + len(reg.Families()) + len(reg.Aliases()); len(adjacency) != expected {
+		t.Fatalf("expected %d nodes, got %d", expected, len(adjacency))
+		return
+	}
+
+	var edges int
+	for id, neighbours := range adjacency {
+		for _, neighbour := range neighbours {
+			if _, ok := adjacency[neighbour]; !ok {
+				t.Fatalf("edge %s -> %s references a missing node", id, neighbour)
+				return
+			}
+		}
+
+		edges += len(neighbours)
+	}
+
+	if expected := csvEdgeCount(t); edges != expected {
+		t.Fatalf("expected %d edges, got %d", expected, edges)
+		return
+	}
+}
+
+// csvEdgeCount counts the relationships in the embedded csv files: the family_id of aircraft types, the parent_family
+// of families and the aircraft_type or aircraft_family of aliases. All of them reference existing records.
+func csvEdgeCount(t *testing.T) int {
+	var err error
+	var edges int
+	for _, file := range []struct {
+		content string
+		columns []string
+	}{
+		{content: types, columns: []string{"family_id"}},
+		{content: families, columns: []string{"parent_family"}},
+		{content: aliases, columns: []string{"aircraft_type", "aircraft_family"}},
+	} {
+		for _, row := range ReadCSV(strings.NewReader(file.content), &err) {
+			for _, column := range file.columns {
+				if row[column] != "" {
+					edges++
+				}
+			}
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return edges
+}
+
+func TestExportRDFTurtle(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n320,32S,320,A320,Airbus A320\n100,,100,F100,\"Fokker \"\"100\"\"\"\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,AIRBUS,family,Airbus A320\nAIRBUS,,,,manufacturer,Airbus\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32A,320,\n32C,,32S\n32X,XXX,\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ExportRDFTurtle(&buf, reg); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	triples, err := parseTurtle(buf.String())
+	if err != nil {
+		t.Fatalf("invalid turtle: %v\n%s", err, buf.String())
+		return
+	}
+
+	for _, triple := range [][3]string{
+		{"ef:type_320", "a", "ef:AircraftType"},
+		{"ef:type_320", "ef:belongsToFamily", "ef:family_32S"},
+		{"ef:type_100", "ef:name", `"Fokker \"100\""`},
+		{"ef:family_32S", "ef:belongsToFamily", "ef:family_AIRBUS"},
+		{"ef:alias_32A", "ef:isAliasFor", "ef:type_320"},
+		{"ef:alias_32C", "ef:isAliasFor", "ef:family_32S"},
+	} {
+		if !slices.Contains(triples, triple) {
+			t.Fatalf("expected triple %v", triple)
+			return
+		}
+	}
+
+	for _, triple := range triples {
+		if triple[1] == "ef:isAliasFor" && triple[0] == "ef:alias_32X" {
+			t.Fatal("expected the dangling alias 32X to have no target")
+			return
+		}
+	}
+
+	embedded, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	buf.Reset()
+	if err := ExportRDFTurtle(&buf, embedded); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err := parseTurtle(buf.String()); err != nil {
+		t.Fatalf("invalid turtle for the embedded data: %v", err)
+		return
+	}
+}
+
+// parseTurtle parses the subset of Turtle written by ExportRDFTurtle: prefix directives
+// and triples using prefixed names, IRIs, string literals and predicate lists.
+func parseTurtle(input string) ([][3]string, error) {
+	var tokens []string
+	for i := 0; i < len(input); {
+		switch c := input[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '.' || c == ';' || c == ',':
+			tokens = append(tokens, string(c))
+			i++
+
+		case c == '<':
+			end := strings.IndexByte(input[i:], '>')
+			if end < 0 || strings.ContainsAny(input[i+1:i+end], " \n\"{}|^`") {
+				return nil, fmt.Errorf("invalid iri at offset %d", i)
+			}
+
+			tokens = append(tokens, input[i:i+end+1])
+			i += end + 1
+
+		case c == '"':
+			j := i + 1
+			for ; j < len(input) && input[j] != '"'; j++ {
+				if input[j] == '\n' {
+					return nil, fmt.Errorf("unterminated string at offset %d", i)
+				} else if input[j] == '\\' {
+					j++
+					if j >= len(input) || !strings.ContainsRune(`tbnrf"'\`, rune(input[j])) {
+						return nil, fmt.Errorf("invalid escape at offset %d", j)
+					}
+				}
+			}
+
+			if j >= len(input) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+
+			tokens = append(tokens, input[i:j+1])
+			i = j + 1
+
+		default:
+			j := i
+			for j < len(input) && !strings.ContainsRune(" \t\r\n;,", rune(input[j])) && !(input[j] == '.' && (j+1 == len(input) || strings.ContainsRune(" \t\r\n", rune(input[j+1])))) {
+				j++
+			}
+
+			tokens = append(tokens, input[i:j])
+			i = j
+		}
+	}
+
+	prefixedName := regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*)?:[A-Za-z0-9_][A-Za-z0-9_-]*$`)
+	prefixes := make(map[string]struct{})
+	term := func(tok string, literalAllowed bool) error {
+		switch {
+		case strings.HasPrefix(tok, "<"):
+			return nil
+		case strings.HasPrefix(tok, `"`):
+			if !literalAllowed {
+				return fmt.Errorf("unexpected literal %s", tok)
+			}
+
+			return nil
+		case prefixedName.MatchString(tok):
+			if _, ok := prefixes[tok[:strings.IndexByte(tok, ':')]]; !ok {
+				return fmt.Errorf("undeclared prefix in %s", tok)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("invalid term %q", tok)
+	}
+
+	var triples [][3]string
+	for len(tokens) > 0 {
+		if tokens[0] == "@prefix" {
+			if len(tokens) < 4 || !strings.HasSuffix(tokens[1], ":") || !strings.HasPrefix(tokens[2], "<") || tokens[3] != "." {
+				return nil, fmt.Errorf("invalid prefix directive %v", tokens[:min(4, len(tokens))])
+			}
+
+			prefixes[strings.TrimSuffix(tokens[1], ":")] = struct{}{}
+			tokens = tokens[4:]
+			continue
+		}
+
+		subject := tokens[0]
+		if err := term(subject, false); err != nil {
+			return nil, err
+		}
+
+		tokens = tokens[1:]
+		for {
+			if len(tokens) < 2 {
+				return nil, fmt.Errorf("incomplete triple for %s", subject)
+			}
+
+			predicate := tokens[0]
+			if predicate != "a" {
+				if err := term(predicate, false); err != nil {
+					return nil, err
+				}
+			}
+
+			tokens = tokens[1:]
+			for {
+				if err := term(tokens[0], predicate != "a"); err != nil {
+					return nil, err
+				}
+
+				triples = append(triples, [3]string{subject, predicate, tokens[0]})
+				tokens = tokens[1:]
+				if len(tokens) == 0 || tokens[0] != "," {
+					break
+				}
+
+				tokens = tokens[1:]
+			}
+
+			if len(tokens) == 0 {
+				return nil, fmt.Errorf("missing . after %s", subject)
+			} else if tokens[0] == "." {
+				tokens = tokens[1:]
+				break
+			} else if tokens[0] != ";" {
+				return nil, fmt.Errorf("unexpected %q after %s", tokens[0], subject)
+			}
+
+			tokens = tokens[1:]
+		}
+	}
+
+	return triples, nil
+}
+
+func TestExportMarkdownTable(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ExportMarkdownTable(&buf, reg); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if lines[0] != "| IATA | ICAO | Name | Family | Body Type |" {
+		t.Fatalf("unexpected header row: %q", lines[0])
+		return
+	}
+
+	if !strings.HasPrefix(lines[1], "|---|") {
+		t.Fatalf("expected a separator row, got %q", lines[1])
+		return
+	}
+
+	if rows := len(lines) - 2; rows != len(reg.Types()) {
+		t.Fatalf("expected %d data rows, got %d", len(reg.Types()), rows)
+		return
+	}
+}
+
+func TestExportMarkdownTableEscaping(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,body_type,name\n321,32S,321,A321,narrowbody,Airbus A321\n320,32S,320,A320,narrowbody,Airbus A320 | ceo\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,,family,Airbus A320 Family\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ExportMarkdownTable(&buf, reg); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	expected := "| IATA | ICAO | Name | Family | Body Type |\n" +
+		"|---|---|---|---|---|\n" +
+		"| 320 | A320 | Airbus A320 \\| ceo | Airbus A320 Family | narrowbody |\n" +
+		"| 321 | A321 | Airbus A321 | Airbus A320 Family | narrowbody |\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+		return
+	}
+}
+
+func TestWriteGraphvizClusterDOT(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	distinct := func(key func(t *AircraftType) (string, bool)) int {
+		keys := make(map[string]struct{})
+		for _, t := range reg.Types() {
+			if k, ok := key(t); ok {
+				keys[k] = struct{}{}
+			}
+		}
+
+		return len(keys)
+	}
+
+	cases := []struct {
+		clusterBy string
+		expected  int
+	}{
+		{clusterBy: "manufacturer", expected: distinct(func(t *AircraftType) (string, bool) {
+			f, ok := reg.Manufacturer(t)
+			if !ok {
+				return "", false
+			}
+
+			return f.Id, true
+		})},
+		{clusterBy: "body_type", expected: distinct(func(t *AircraftType) (string, bool) {
+			return string(t.BodyType), t.BodyType != ""
+		})},
+		{clusterBy: "family", expected: distinct(func(t *AircraftType) (string, bool) {
+			_, ok := reg.Family(t.FamilyId)
+			return t.FamilyId, ok
+		})},
+		{clusterBy: "none", expected: 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.clusterBy, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteGraphvizClusterDOT(&buf, reg, c.clusterBy); err != nil {
+				t.Fatal(err)
+				return
+			}
+
+			graph, err := graphviz.ParseBytes(buf.Bytes())
+			if err != nil {
+				t.Fatal(err)
+				return
+			}
+			defer graph.Close()
+
+			if n, err := graph.NodeNum(); err != nil || n != len(reg.Types())+len(reg.Families())+len(reg.Aliases()) {
+				t.Fatalf("expected a node for every aircraft type, family and alias, got %d, %v", n, err)
+				return
+			}
+
+			if n := strings.Count(buf.String(), "subgraph cluster_"); n != c.expected {
+				t.Fatalf("expected %d clusters, got %d", c.expected, n)
+				return
+			}
+		})
+	}
+}
+
+func TestWriteGraphvizClusterDOTUnsupportedKey(t *testing.T) {
+	if err := WriteGraphvizClusterDOT(io.Discard, newEmptyRegistry(), "engine_type"); err == nil {
+		t.Fatal("expected error for unsupported cluster key")
+		return
+	}
+}
@@ -0,0 +1,70 @@
+package referencedata
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// FuzzySearchByName returns up to maxResults aircraft types whose name is most similar to query,
+// ordered by descending trigram similarity. Types without any trigram in common with query are omitted.
+func (r *Registry) FuzzySearchByName(query string, maxResults int) []*AircraftType {
+	queryTrigrams := trigrams(query)
+	if len(queryTrigrams) == 0 || maxResults <= 0 {
+		return []*AircraftType{}
+	}
+
+	type match struct {
+		t     *AircraftType
+		score float64
+	}
+
+	var matches []match
+	for _, t := range r.types {
+		if score := trigramSimilarity(queryTrigrams, trigrams(t.Name)); score > 0 {
+			matches = append(matches, match{t, score})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b match) int {
+		return cmp.Compare(b.score, a.score)
+	})
+
+	results := make([]*AircraftType, 0, min(len(matches), maxResults))
+	for _, m := range matches[:min(len(matches), maxResults)] {
+		results = append(results, m.t)
+	}
+
+	return results
+}
+
+// trigrams returns the set of trigrams of the lower cased words of s.
+// Words are padded so that their start and end form trigrams of their own.
+func trigrams(s string) map[string]struct{} {
+	result := make(map[string]struct{})
+	for _, word := range strings.Fields(strings.ToLower(s)) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			result[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// trigramSimilarity returns the Jaccard index of the trigram sets a and b.
+func trigramSimilarity(a, b map[string]struct{}) float64 {
+	var shared int
+	for t := range a {
+		if _, ok := b[t]; ok {
+			shared++
+		}
+	}
+
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 0
+	}
+
+	return float64(shared) / float64(union)
+}
@@ -0,0 +1,37 @@
+package referencedata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFuzzySearchByName(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	results := reg.FuzzySearchByName("Boening 737", 3)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+		return
+	}
+
+	for _, result := range results {
+		if !strings.HasPrefix(result.Name, "Boeing 737") {
+			t.Fatalf("expected the top results for \"Boening 737\" to be Boeing 737 variants, got %q", result.Name)
+			return
+		}
+	}
+
+	if results := reg.FuzzySearchByName("Airbus A32O", 1); len(results) != 1 || results[0].Id != "320" {
+		t.Fatalf("expected Airbus A320 as best match for \"Airbus A32O\", got %v", results)
+		return
+	}
+
+	if results := reg.FuzzySearchByName("", 3); results == nil || len(results) != 0 {
+		t.Fatalf("expected an empty slice for an empty query, got %v", results)
+		return
+	}
+}
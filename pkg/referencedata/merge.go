@@ -0,0 +1,93 @@
+package referencedata
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeConflict is a field whose value differs between the base and the overlay of a merge.
+// Id is the primary key of the row. Keys of several columns are joined by a slash, for seat configurations
+// they are the aircraft type id and the configuration name, for photos the aircraft type id, sort order and url,
+// for the deprecation log the old and the new IATA code and for type certificates the aircraft type id and the authority.
+type MergeConflict struct {
+	Table        string
+	Id           string
+	Field        string
+	BaseValue    string
+	OverlayValue string
+}
+
+// MergeRegistry returns a registry combining the records of base and overlay.
+// Rows with the same primary key are taken from overlay, and every field in which they differ is reported as conflict.
+// Rows only present in overlay are added after the rows of base. Rows missing from overlay are kept.
+// The merged registry shares its records with base and overlay, except for families which are copied
+// because their depth depends on the merged hierarchy.
+// The merged registry is validated like the validate subcommand does, the conflicts are returned even if validation fails.
+func MergeRegistry(base, overlay *Registry) (*Registry, []MergeConflict, error) {
+	var conflicts []MergeConflict
+	r := newEmptyRegistry()
+
+	mergeTable(&conflicts, "aircraft_types", aircraftTypesSchema, 1, base.Types(), overlay.Types(), aircraftTypeFields, r.addType)
+	mergeTable(&conflicts, "aircraft_families", aircraftFamiliesSchema, 1, base.Families(), overlay.Families(), aircraftFamilyFields, func(f *AircraftFamily) {
+		copied := *f
+		r.addFamily(&copied)
+	})
+	mergeTable(&conflicts, "aircraft_aliases", aircraftAliasesSchema, 1, base.Aliases(), overlay.Aliases(), aircraftAliasFields, r.addAlias)
+	mergeTable(&conflicts, "aircraft_variants", aircraftVariantsSchema, 1, base.Variants(), overlay.Variants(), aircraftVariantFields, r.addVariant)
+	mergeTable(&conflicts, "aircraft_type_performance", aircraftPerformanceSchema, 1, base.Performances(), overlay.Performances(), aircraftPerformanceFields, r.addPerformance)
+	mergeTable(&conflicts, "aircraft_type_photos", aircraftPhotosSchema, 3, base.Photos(), overlay.Photos(), aircraftPhotoFields, r.addPhoto)
+	mergeTable(&conflicts, "aircraft_seat_configurations", seatConfigurationsSchema, 2, base.SeatConfigurations(), overlay.SeatConfigurations(), seatConfigurationFields, r.addSeatConfiguration)
+	mergeTable(&conflicts, "aircraft_types_deprecation_log", deprecationLogSchema, 2, base.DeprecationEntries(), overlay.DeprecationEntries(), deprecationEntryFields, r.addDeprecationEntry)
+	mergeTable(&conflicts, "aircraft_regulatory", regulatorySchema, 2, base.RegulatoryEntries(), overlay.RegulatoryEntries(), regulatoryEntryFields, r.addRegulatoryEntry)
+	mergeTable(&conflicts, "historical_aliases", historicalAliasesSchema, 1, base.HistoricalAliases(), overlay.HistoricalAliases(), historicalAliasFields, r.addHistoricalAlias)
+	mergeTable(&conflicts, "aircraft_type_names", aircraftTypeNamesSchema, 2, base.TypeNames(), overlay.TypeNames(), aircraftTypeNameFields, r.addTypeName)
+	mergeTable(&conflicts, "countries", countriesSchema, 1, base.Countries(), overlay.Countries(), countryFields, r.addCountry)
+
+	r.computeFamilyDepths()
+	if err := validateRegistry(r); err != nil {
+		return nil, conflicts, fmt.Errorf("merged registry is invalid: %w", err)
+	}
+
+	return r, conflicts, nil
+}
+
+// mergeTable adds the merged rows of base and overlay through add and appends the conflicting fields to conflicts.
+// The primary key of a row consists of its first keyColumns fields.
+func mergeTable[T any](conflicts *[]MergeConflict, table string, columns []string, keyColumns int, base, overlay []T, fields func(T) []string, add func(T)) {
+	key := func(values []string) string {
+		return strings.Join(values[:keyColumns], "/")
+	}
+
+	overlayByKey := make(map[string]T, len(overlay))
+	for _, row := range overlay {
+		overlayByKey[key(fields(row))] = row
+	}
+
+	baseKeys := make(map[string]struct{}, len(base))
+	for _, row := range base {
+		baseValues := fields(row)
+		k := key(baseValues)
+		baseKeys[k] = struct{}{}
+
+		overlayRow, ok := overlayByKey[k]
+		if !ok {
+			add(row)
+			continue
+		}
+
+		overlayValues := fields(overlayRow)
+		for i, column := range columns {
+			if baseValues[i] != overlayValues[i] {
+				*conflicts = append(*conflicts, MergeConflict{Table: table, Id: k, Field: column, BaseValue: baseValues[i], OverlayValue: overlayValues[i]})
+			}
+		}
+
+		add(overlayRow)
+	}
+
+	for _, row := range overlay {
+		if _, ok := baseKeys[key(fields(row))]; !ok {
+			add(row)
+		}
+	}
+}
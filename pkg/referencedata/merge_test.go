@@ -0,0 +1,243 @@
+package referencedata
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMergeRegistry(t *testing.T) {
+	base, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n321,,321,A321,Airbus A321\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32A,320,\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	overlay, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320ceo\n32N,,32N,A20N,Airbus A320neo\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32A,320,\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	merged, conflicts, err := MergeRegistry(base, overlay)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	expected := []MergeConflict{{Table: "aircraft_types", Id: "320", Field: "name", BaseValue: "Airbus A320", OverlayValue: "Airbus A320ceo"}}
+	if !slices.Equal(conflicts, expected) {
+		t.Fatalf("expected conflicts %v, got %v", expected, conflicts)
+		return
+	}
+
+	if at, ok := merged.Type("320"); !ok || at.Name != "Airbus A320ceo" {
+		t.Fatalf("expected the overlay name to win, got %+v", at)
+		return
+	}
+
+	var ids []string
+	for _, at := range merged.Types() {
+		ids = append(ids, at.Id)
+	}
+
+	if expected := []string{"320", "321", "32N"}; !slices.Equal(ids, expected) {
+		t.Fatalf("expected aircraft types %v, got %v", expected, ids)
+		return
+	}
+
+	if len(merged.Aliases()) != 1 {
+		t.Fatalf("expected identical aliases to be merged, got %v", merged.Aliases())
+		return
+	}
+}
+
+func TestMergeRegistryFamilyDepth(t *testing.T) {
+	base, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,,family,Airbus A320\nAIRBUS,,,,manufacturer,Airbus\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	overlay, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,AIRBUS,family,Airbus A320\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	merged, _, err := MergeRegistry(base, overlay)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if f, ok := merged.Family("32S"); !ok || f.Depth() != 1 {
+		t.Fatalf("expected depth 1 for 32S below AIRBUS in the merged registry, got %+v", f)
+		return
+	}
+
+	if f, ok := base.Family("AIRBUS"); !ok || f.Depth() != 0 {
+		t.Fatalf("expected the families of base to be unchanged, got %+v", f)
+		return
+	}
+
+	if f, ok := overlay.Family("32S"); !ok || f.Depth() != 0 {
+		t.Fatalf("expected the families of overlay to be unchanged, got %+v", f)
+		return
+	}
+}
+
+func TestMergeRegistrySupplements(t *testing.T) {
+	base, overlay := newMergeRegistries(t)
+	for _, load := range []func() error{
+		func() error {
+			return base.loadSeatConfigurations(strings.NewReader("aircraft_type_id,config_name,first_seats,business_seats,premium_economy_seats,economy_seats\n320,two class,0,12,0,138\n321,two class,0,16,0,174\n"))
+		},
+		func() error {
+			return base.loadPerformance(strings.NewReader("aircraft_type_id,cruise_speed_kmh,service_ceiling_ft,takeoff_distance_m,landing_distance_m\n320,828,39800,2100,1500\n321,828,39800,2500,1600\n"))
+		},
+		func() error {
+			return overlay.loadPerformance(strings.NewReader("aircraft_type_id,cruise_speed_kmh,service_ceiling_ft,takeoff_distance_m,landing_distance_m\n321,828,39800,2400,1600\n"))
+		},
+		func() error {
+			return base.loadDeprecationLog(strings.NewReader("old_iata,new_iata,aircraft_type_id,change_year,reason\n32S,320,320,2015,split into 319 320 321\n"))
+		},
+		func() error {
+			return overlay.loadDeprecationLog(strings.NewReader("old_iata,new_iata,aircraft_type_id,change_year,reason\n32S,321,321,2015,split into 319 320 321\n"))
+		},
+		func() error {
+			return base.loadRegulatory(strings.NewReader("aircraft_type_id,authority,certificate_number,issue_year\n320,EASA,A.064,1988\n320,FAA,A28NM,1988\n"))
+		},
+		func() error {
+			return overlay.loadRegulatory(strings.NewReader("aircraft_type_id,authority,certificate_number,issue_year\n320,EASA,EASA.A.064,1988\n"))
+		},
+		func() error {
+			return base.loadPhotos(strings.NewReader("aircraft_type_id,sort_order,url,attribution,license\n320,1,https://example.com/320.jpg,Jane Doe,CC-BY-4.0\n"))
+		},
+		func() error {
+			return overlay.loadPhotos(strings.NewReader("aircraft_type_id,sort_order,url,attribution,license\n321,1,https://example.com/321.jpg,John Doe,CC0-1.0\n"))
+		},
+		func() error {
+			return overlay.loadSeatConfigurations(strings.NewReader("aircraft_type_id,config_name,first_seats,business_seats,premium_economy_seats,economy_seats\n320,all economy,0,0,0,180\n321,two class,0,20,0,170\n"))
+		},
+	} {
+		if err := load(); err != nil {
+			t.Fatal(err)
+			return
+		}
+	}
+
+	merged, conflicts, err := MergeRegistry(base, overlay)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if configs := merged.ConfigurationsFor("320"); len(configs) != 2 || configs[0].ConfigName != "two class" || configs[1].ConfigName != "all economy" {
+		t.Fatalf("expected the seat configurations of base and overlay for 320, got %v", configs)
+		return
+	}
+
+	if configs := merged.ConfigurationsFor("321"); len(configs) != 1 || configs[0].BusinessSeats != 20 {
+		t.Fatalf("expected the overlay seat configuration of 321 to win, got %v", configs)
+		return
+	}
+
+	if performance, err := merged.Performance("321"); err != nil || performance.TakeoffDistanceM != 2400 {
+		t.Fatalf("expected the overlay performance of 321, got %+v, %v", performance, err)
+		return
+	}
+
+	if entries := merged.DeprecationLog("32S"); len(entries) != 2 {
+		t.Fatalf("expected both deprecation entries of 32S, got %v", entries)
+		return
+	}
+
+	if certificates := merged.RegulatoryCertificates("320"); len(certificates) != 2 || certificates[0].CertificateNumber != "EASA.A.064" {
+		t.Fatalf("expected the EASA certificate of the overlay and the FAA certificate of the base, got %v", certificates)
+		return
+	}
+
+	for _, id := range []string{"320", "321"} {
+		if photo, err := merged.PrimaryPhoto(id); err != nil || photo.URL != "https://example.com/"+id+".jpg" {
+			t.Fatalf("expected the photo of %s, got %+v, %v", id, photo, err)
+			return
+		}
+	}
+
+	expected := []MergeConflict{
+		{Table: "aircraft_type_performance", Id: "321", Field: "takeoff_distance_m", BaseValue: "2500", OverlayValue: "2400"},
+		{Table: "aircraft_seat_configurations", Id: "321/two class", Field: "business_seats", BaseValue: "16", OverlayValue: "20"},
+		{Table: "aircraft_seat_configurations", Id: "321/two class", Field: "economy_seats", BaseValue: "174", OverlayValue: "170"},
+		{Table: "aircraft_regulatory", Id: "320/EASA", Field: "certificate_number", BaseValue: "A.064", OverlayValue: "EASA.A.064"},
+	}
+
+	if !slices.Equal(conflicts, expected) {
+		t.Fatalf("expected conflicts %v, got %v", expected, conflicts)
+		return
+	}
+}
+
+// newMergeRegistries returns a base and an overlay registry with the same aircraft types 320 and 321
+// and no supplementary records.
+func newMergeRegistries(t *testing.T) (*Registry, *Registry) {
+	t.Helper()
+	var registries []*Registry
+	for range 2 {
+		reg, err := newRegistry(
+			strings.NewReader("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n321,,321,A321,Airbus A321\n"),
+			strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+			strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		registries = append(registries, reg)
+	}
+
+	return registries[0], registries[1]
+}
+
+func TestMergeRegistryInvalid(t *testing.T) {
+	base, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	overlay, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,320,,,family,Airbus A320\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, _, err := MergeRegistry(base, overlay); err == nil {
+		t.Fatal("expected an error for a merge creating an iata conflict")
+		return
+	}
+}
@@ -0,0 +1,148 @@
+package referencedata
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/explore-flights/reference-data/internal/atomicfile"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MutableRegistry is a Registry whose records can be changed and written back to csv files.
+// Changes are made to the records of the embedded Registry in place.
+// A MutableRegistry is not safe for concurrent use.
+type MutableRegistry struct {
+	*Registry
+	// dirty holds the names of the files with changed records.
+	dirty map[string]struct{}
+}
+
+// NewMutableRegistry returns a MutableRegistry changing the records of reg.
+func NewMutableRegistry(reg *Registry) *MutableRegistry {
+	return &MutableRegistry{
+		Registry: reg,
+		dirty:    make(map[string]struct{}),
+	}
+}
+
+// MarkDeprecated marks the aircraft type with the given id as deprecated since the given year.
+// supersededBy is the id of the aircraft type replacing it and may be empty if there is none.
+func (r *MutableRegistry) MarkDeprecated(id string, since int, supersededBy string) error {
+	t, ok := r.typeById[id]
+	if !ok {
+		return fmt.Errorf("aircraft type %q: %w", id, ErrNotFound)
+	}
+
+	if since <= 0 {
+		return fmt.Errorf("aircraft type %q: invalid retired year %d", id, since)
+	}
+
+	if supersededBy != "" {
+		if supersededBy == id {
+			return fmt.Errorf("aircraft type %q cannot be superseded by itself", id)
+		} else if _, ok := r.typeById[supersededBy]; !ok {
+			return fmt.Errorf("superseded_by %q: %w", supersededBy, ErrNotFound)
+		} else if r.isSupersededBy(supersededBy, id) {
+			return fmt.Errorf("aircraft type %q cannot be superseded by %q which is already superseded by it", id, supersededBy)
+		}
+	}
+
+	t.Deprecated = true
+	t.RetiredYear = since
+	t.SupersededBy = supersededBy
+	r.dirty["aircraft_types.csv"] = struct{}{}
+	r.statsOnce, r.stats = sync.Once{}, nil
+
+	return nil
+}
+
+// isSupersededBy reports whether the aircraft type with the given id is reached by following the superseded_by chain of
+// the aircraft type with the id from. A cycle in the existing chain ends the search.
+func (r *MutableRegistry) isSupersededBy(from, id string) bool {
+	visited := make(map[string]struct{})
+	for t, ok := r.typeById[from]; ok && t.SupersededBy != ""; t, ok = r.typeById[t.SupersededBy] {
+		if t.SupersededBy == id {
+			return true
+		} else if _, ok := visited[t.Id]; ok {
+			return false
+		}
+
+		visited[t.Id] = struct{}{}
+	}
+
+	return false
+}
+
+// Dirty reports whether there are changes which have not been saved yet.
+func (r *MutableRegistry) Dirty() bool {
+	return len(r.dirty) > 0
+}
+
+// SaveToDir writes every csv file with changed records to dir, replacing existing files.
+// Files without changes are not written. Rows are written in file order.
+func (r *MutableRegistry) SaveToDir(dir string) error {
+	files := r.csvFiles(false)
+
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(r.dirty)) {
+		if err := atomicfile.Write(filepath.Join(dir, name), files[name]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+
+		delete(r.dirty, name)
+	}
+
+	return errors.Join(errs...)
+}
+
+// SaveToCSV writes aircraft_types.csv, aircraft_families.csv and aircraft_aliases.csv to dir, replacing existing files.
+// The columns are in the order of the embedded files and the rows are sorted by primary key.
+func (r *Registry) SaveToCSV(dir string) error {
+	files := r.csvFiles(true)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if err := atomicfile.Write(filepath.Join(dir, name), files[name]); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// csvFiles returns functions writing the main csv files of r by file name.
+func (r *Registry) csvFiles(sortByKey bool) map[string]func(w io.Writer) error {
+	return map[string]func(w io.Writer) error{
+		"aircraft_types.csv": func(w io.Writer) error {
+			return writeCsv(w, aircraftTypesSchema, r.types, aircraftTypeFields, sortByKey)
+		},
+		"aircraft_families.csv": func(w io.Writer) error {
+			return writeCsv(w, aircraftFamiliesSchema, r.families, aircraftFamilyFields, sortByKey)
+		},
+		"aircraft_aliases.csv": func(w io.Writer) error {
+			return writeCsv(w, aircraftAliasesSchema, r.aliases, aircraftAliasFields, sortByKey)
+		},
+	}
+}
+
+// writeCsv writes the header followed by the fields of every row to w.
+// If sortByKey is set the rows are sorted by their first field, otherwise they are written in the given order.
+func writeCsv[T any](w io.Writer, header []string, rows []T, fields func(T) []string, sortByKey bool) error {
+	records := make([][]string, 0, len(rows)+1)
+	records = append(records, header)
+	for _, row := range rows {
+		records = append(records, fields(row))
+	}
+
+	if sortByKey {
+		slices.SortStableFunc(records[1:], func(a, b []string) int {
+			return strings.Compare(a[0], b[0])
+		})
+	}
+
+	return csv.NewWriter(w).WriteAll(records)
+}
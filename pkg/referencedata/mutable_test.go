@@ -0,0 +1,277 @@
+package referencedata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMarkDeprecated(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	mr := NewMutableRegistry(reg)
+	if err := mr.MarkDeprecated("does-not-exist", 2020, ""); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unknown id, got %v", err)
+		return
+	}
+
+	if err := mr.MarkDeprecated("312", 2020, "does-not-exist"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unknown successor, got %v", err)
+		return
+	}
+
+	if mr.Dirty() {
+		t.Fatal("expected failed changes to leave the registry clean")
+		return
+	}
+
+	if err := mr.MarkDeprecated("312", 1998, "332"); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	dir := t.TempDir()
+	if err := mr.SaveToDir(dir); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if mr.Dirty() {
+		t.Fatal("expected the registry to be clean after saving")
+		return
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "aircraft_families.csv")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected unchanged files not to be written, got %v", err)
+		return
+	}
+
+	reloaded, err := NewRegistryFromPaths(filepath.Join(dir, "aircraft_types.csv"), "", "")
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	at, ok := reloaded.Type("312")
+	if !ok || !at.Deprecated || at.RetiredYear != 1998 || at.SupersededBy != "332" {
+		t.Fatalf("expected 312 to be deprecated after reload, got %+v", at)
+		return
+	}
+
+	if len(reloaded.Types()) != len(reg.Types()) {
+		t.Fatalf("expected %d aircraft types after reload, got %d", len(reg.Types()), len(reloaded.Types()))
+		return
+	}
+}
+
+func TestMarkDeprecatedRejectsCycle(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n310,,310,A310,Airbus A310\n320,,320,A320,Airbus A320\n321,,321,A321,Airbus A321\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	mr := NewMutableRegistry(reg)
+	if err := mr.MarkDeprecated("310", 2000, "320"); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err := mr.MarkDeprecated("320", 2010, "321"); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, successor := range []string{"310", "320"} {
+		if err := mr.MarkDeprecated("321", 2020, successor); err == nil {
+			t.Fatalf("expected superseding 321 by %s to be rejected as cycle", successor)
+			return
+		}
+	}
+
+	if at, _ := reg.Type("321"); at.Deprecated || at.SupersededBy != "" {
+		t.Fatalf("expected the rejected change to leave 321 unchanged, got %+v", at)
+		return
+	}
+}
+
+func TestSaveToDirRoundTrip(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	mr := NewMutableRegistry(reg)
+	mr.dirty["aircraft_types.csv"] = struct{}{}
+	mr.dirty["aircraft_families.csv"] = struct{}{}
+	mr.dirty["aircraft_aliases.csv"] = struct{}{}
+
+	dir := t.TempDir()
+	if err := mr.SaveToDir(dir); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for name, embedded := range map[string]string{"aircraft_types.csv": types, "aircraft_families.csv": families, "aircraft_aliases.csv": aliases} {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		if string(b) != strings.ReplaceAll(embedded, "\r\n", "\n") {
+			t.Fatalf("expected %s to be written unchanged", name)
+			return
+		}
+	}
+}
+
+func TestSaveToCSV(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	dir := t.TempDir()
+	if err := reg.SaveToCSV(dir); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	reloaded, err := NewRegistryFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	compareRows(t, "aircraft types", aircraftTypesSchema, reg.Types(), reloaded.Types(), aircraftTypeFields)
+	compareRows(t, "aircraft families", aircraftFamiliesSchema, reg.Families(), reloaded.Families(), aircraftFamilyFields)
+	compareRows(t, "aircraft aliases", aircraftAliasesSchema, reg.Aliases(), reloaded.Aliases(), aircraftAliasFields)
+}
+
+func TestRoundTripCSV(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	dir := t.TempDir()
+	if err := reg.SaveToCSV(dir); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for name, original := range map[string]string{
+		"aircraft_types.csv":    types,
+		"aircraft_families.csv": families,
+		"aircraft_aliases.csv":  aliases,
+	} {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		if expected, actual := strings.Count(original, "\n"), bytes.Count(b, []byte("\n")); expected != actual {
+			t.Fatalf("expected %d lines in %s, got %d", expected, name, actual)
+			return
+		}
+	}
+
+	reloaded, err := NewRegistryFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	compareRows(t, "aircraft types", aircraftTypesSchema, reg.Types(), reloaded.Types(), aircraftTypeFields)
+	compareRows(t, "aircraft families", aircraftFamiliesSchema, reg.Families(), reloaded.Families(), aircraftFamilyFields)
+	compareRows(t, "aircraft aliases", aircraftAliasesSchema, reg.Aliases(), reloaded.Aliases(), aircraftAliasFields)
+}
+
+func TestSaveToCSVNotesRoundTrip(t *testing.T) {
+	notes := []string{
+		"replaced by the 320, see the 321 for the stretch",
+		`known as the "Baby Bus"`,
+		"first line\nsecond line",
+		"comma, \"quote\" and\nnewline",
+	}
+
+	var sb strings.Builder
+	sb.WriteString("id,family_id,iata,icao,notes,name\n")
+	for i, note := range notes {
+		fmt.Fprintf(&sb, "NT%d,,NT%d,,\"%s\",Aircraft %d\n", i, i, strings.ReplaceAll(note, `"`, `""`), i)
+	}
+
+	reg, err := newRegistry(strings.NewReader(sb.String()), strings.NewReader("id,iata,icao,parent_family,level,name\n"), strings.NewReader("alias,aircraft_type,aircraft_family\n"))
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	dir := t.TempDir()
+	if err := reg.SaveToCSV(dir); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	reloaded, err := NewRegistryFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for i, note := range notes {
+		id := fmt.Sprintf("NT%d", i)
+		if at, ok := reloaded.Type(id); !ok || at.Notes != note {
+			t.Fatalf("expected notes %q of %s after reload, got %+v", note, id, at)
+			return
+		}
+	}
+}
+
+// compareRows fails the test unless expected and actual contain the same rows with equal fields, regardless of order.
+func compareRows[T any](t *testing.T, table string, columns []string, expected, actual []T, fields func(T) []string) {
+	t.Helper()
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %d %s, got %d", len(expected), table, len(actual))
+		return
+	}
+
+	actualByKey := make(map[string][]string, len(actual))
+	for _, row := range actual {
+		values := fields(row)
+		actualByKey[values[0]] = values
+	}
+
+	for _, row := range expected {
+		expectedValues := fields(row)
+		actualValues, ok := actualByKey[expectedValues[0]]
+		if !ok {
+			t.Fatalf("missing %s row %q", table, expectedValues[0])
+			return
+		}
+
+		for i, column := range columns {
+			if expectedValues[i] != actualValues[i] {
+				t.Fatalf("expected %s of %s row %q to be %q, got %q", column, table, expectedValues[0], expectedValues[i], actualValues[i])
+				return
+			}
+		}
+	}
+}
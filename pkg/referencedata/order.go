@@ -0,0 +1,40 @@
+package referencedata
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCyclicDependency is returned by TopologicalOrder if the parent families form a cycle.
+var ErrCyclicDependency = errors.New("cyclic dependency")
+
+// TopologicalOrder returns all families of reg ordered so that every family comes after its parent,
+// followed by all aircraft types. Families whose parent is not part of reg are treated as roots.
+// Siblings keep their file order.
+func TopologicalOrder(reg *Registry) ([]*AircraftFamily, []*AircraftType, error) {
+	var families []*AircraftFamily
+	for _, f := range reg.Families() {
+		if _, ok := reg.Family(f.ParentFamilyId); !ok {
+			families = append(families, f)
+		}
+	}
+
+	for i := 0; i < len(families); i++ {
+		families = append(families, reg.familiesByParentId[families[i].Id]...)
+	}
+
+	if len(families) != len(reg.Families()) {
+		placed := make(map[string]struct{}, len(families))
+		for _, f := range families {
+			placed[f.Id] = struct{}{}
+		}
+
+		for _, f := range reg.Families() {
+			if _, ok := placed[f.Id]; !ok {
+				return nil, nil, fmt.Errorf("family %q: %w", f.Id, ErrCyclicDependency)
+			}
+		}
+	}
+
+	return families, reg.Types(), nil
+}
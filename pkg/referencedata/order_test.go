@@ -0,0 +1,59 @@
+package referencedata
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTopologicalOrder(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	families, aircraftTypes, err := TopologicalOrder(reg)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(families) != len(reg.Families()) || len(aircraftTypes) != len(reg.Types()) {
+		t.Fatalf("expected %d families and %d types, got %d and %d", len(reg.Families()), len(reg.Types()), len(families), len(aircraftTypes))
+		return
+	}
+
+	indexById := make(map[string]int, len(families))
+	for i, f := range families {
+		indexById[f.Id] = i
+	}
+
+	for i, f := range families {
+		if f.ParentFamilyId == "" {
+			continue
+		}
+
+		if parentIdx, ok := indexById[f.ParentFamilyId]; !ok || parentIdx >= i {
+			t.Fatalf("family %q at index %d does not come after its parent %q", f.Id, i, f.ParentFamilyId)
+			return
+		}
+	}
+}
+
+func TestTopologicalOrderCycle(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\nAIRBUS,,,,manufacturer,Airbus\nA,,,B,family,A\nB,,,A,family,B\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, _, err := TopologicalOrder(reg); !errors.Is(err, ErrCyclicDependency) {
+		t.Fatalf("expected ErrCyclicDependency, got %v", err)
+		return
+	}
+}
@@ -0,0 +1,591 @@
+package referencedata
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+)
+
+// csvRecord is a single row of a csv file with access to its fields by column name.
+type csvRecord struct {
+	columns map[string]int
+	fields  []string
+}
+
+func (r csvRecord) get(column string) string {
+	if i, ok := r.columns[column]; ok && i < len(r.fields) {
+		return r.fields[i]
+	}
+
+	return ""
+}
+
+// readRecords is like ReadCSV but resolves the header once instead of allocating a map per row.
+// The fields of a yielded record are only valid until the next iteration.
+func readRecords(reader io.Reader, outErr *error) iter.Seq2[int, csvRecord] {
+	var columns map[string]int
+	return readCsvTyped(reader, func(headers, record []string) (csvRecord, error) {
+		if columns == nil {
+			columns = make(map[string]int, len(headers))
+			for i, colName := range headers {
+				columns[colName] = i
+			}
+		}
+
+		return csvRecord{columns: columns, fields: record}, nil
+	}, outErr)
+}
+
+// AircraftTypes parses rows of aircraft_types.csv from reader.
+// Iteration stops at the first invalid row, the error is stored in outErr.
+func AircraftTypes(reader io.Reader, outErr *error) iter.Seq2[int, *AircraftType] {
+	return func(yield func(int, *AircraftType) bool) {
+		for line, rec := range readRecords(reader, outErr) {
+			t, err := parseAircraftType(rec)
+			if err != nil {
+				*outErr = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+
+			if !yield(line, t) {
+				return
+			}
+		}
+	}
+}
+
+// AircraftFamilies parses rows of aircraft_families.csv from reader.
+// Iteration stops at the first invalid row, the error is stored in outErr.
+func AircraftFamilies(reader io.Reader, outErr *error) iter.Seq2[int, *AircraftFamily] {
+	return func(yield func(int, *AircraftFamily) bool) {
+		for line, rec := range readRecords(reader, outErr) {
+			f, err := parseAircraftFamily(rec)
+			if err != nil {
+				*outErr = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+
+			if !yield(line, f) {
+				return
+			}
+		}
+	}
+}
+
+// AircraftAliases parses rows of aircraft_aliases.csv from reader.
+// Iteration stops at the first invalid row, the error is stored in outErr.
+func AircraftAliases(reader io.Reader, outErr *error) iter.Seq2[int, *AircraftAlias] {
+	return func(yield func(int, *AircraftAlias) bool) {
+		for line, rec := range readRecords(reader, outErr) {
+			a, err := parseAircraftAlias(rec)
+			if err != nil {
+				*outErr = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+
+			if !yield(line, a) {
+				return
+			}
+		}
+	}
+}
+
+// AircraftVariants parses rows of aircraft_variants.csv from reader.
+// Iteration stops at the first invalid row, the error is stored in outErr.
+func AircraftVariants(reader io.Reader, outErr *error) iter.Seq2[int, *AircraftVariant] {
+	return func(yield func(int, *AircraftVariant) bool) {
+		for line, rec := range readRecords(reader, outErr) {
+			v, err := parseAircraftVariant(rec)
+			if err != nil {
+				*outErr = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+
+			if !yield(line, v) {
+				return
+			}
+		}
+	}
+}
+
+// DeprecationEntries parses rows of aircraft_types_deprecation_log.csv from reader.
+// Iteration stops at the first invalid row, the error is stored in outErr.
+func DeprecationEntries(reader io.Reader, outErr *error) iter.Seq2[int, *DeprecationEntry] {
+	return func(yield func(int, *DeprecationEntry) bool) {
+		for line, rec := range readRecords(reader, outErr) {
+			e, err := parseDeprecationEntry(rec)
+			if err != nil {
+				*outErr = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+
+			if !yield(line, e) {
+				return
+			}
+		}
+	}
+}
+
+// RegulatoryEntries parses rows of aircraft_regulatory.csv from reader.
+// Iteration stops at the first invalid row, the error is stored in outErr.
+func RegulatoryEntries(reader io.Reader, outErr *error) iter.Seq2[int, *RegulatoryEntry] {
+	return func(yield func(int, *RegulatoryEntry) bool) {
+		for line, rec := range readRecords(reader, outErr) {
+			e, err := parseRegulatoryEntry(rec)
+			if err != nil {
+				*outErr = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+
+			if !yield(line, e) {
+				return
+			}
+		}
+	}
+}
+
+// AircraftPerformances parses rows of aircraft_type_performance.csv from reader.
+// Iteration stops at the first invalid row, the error is stored in outErr.
+func AircraftPerformances(reader io.Reader, outErr *error) iter.Seq2[int, *AircraftPerformance] {
+	return func(yield func(int, *AircraftPerformance) bool) {
+		for line, rec := range readRecords(reader, outErr) {
+			p, err := parseAircraftPerformance(rec)
+			if err != nil {
+				*outErr = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+
+			if !yield(line, p) {
+				return
+			}
+		}
+	}
+}
+
+// AircraftPhotos parses rows of aircraft_type_photos.csv from reader.
+// Iteration stops at the first invalid row, the error is stored in outErr.
+func AircraftPhotos(reader io.Reader, outErr *error) iter.Seq2[int, *AircraftPhoto] {
+	return func(yield func(int, *AircraftPhoto) bool) {
+		for line, rec := range readRecords(reader, outErr) {
+			p, err := parseAircraftPhoto(rec)
+			if err != nil {
+				*outErr = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+
+			if !yield(line, p) {
+				return
+			}
+		}
+	}
+}
+
+// SeatConfigurations parses rows of aircraft_seat_configurations.csv from reader.
+// Iteration stops at the first invalid row, the error is stored in outErr.
+func SeatConfigurations(reader io.Reader, outErr *error) iter.Seq2[int, *SeatConfiguration] {
+	return func(yield func(int, *SeatConfiguration) bool) {
+		for line, rec := range readRecords(reader, outErr) {
+			c, err := parseSeatConfiguration(rec)
+			if err != nil {
+				*outErr = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+
+			if !yield(line, c) {
+				return
+			}
+		}
+	}
+}
+
+// HistoricalAliases parses rows of historical_aliases.csv from reader.
+// Iteration stops at the first invalid row, the error is stored in outErr.
+func HistoricalAliases(reader io.Reader, outErr *error) iter.Seq2[int, *HistoricalAlias] {
+	return func(yield func(int, *HistoricalAlias) bool) {
+		for line, rec := range readRecords(reader, outErr) {
+			h, err := parseHistoricalAlias(rec)
+			if err != nil {
+				*outErr = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+
+			if !yield(line, h) {
+				return
+			}
+		}
+	}
+}
+
+// AircraftTypeNames parses rows of aircraft_type_names.csv from reader.
+// Iteration stops at the first invalid row, the error is stored in outErr.
+func AircraftTypeNames(reader io.Reader, outErr *error) iter.Seq2[int, *AircraftTypeName] {
+	return func(yield func(int, *AircraftTypeName) bool) {
+		for line, rec := range readRecords(reader, outErr) {
+			n := &AircraftTypeName{
+				AircraftTypeId: rec.get("aircraft_type_id"),
+				Locale:         rec.get("locale"),
+				Name:           rec.get("name"),
+			}
+
+			if !yield(line, n) {
+				return
+			}
+		}
+	}
+}
+
+// Countries parses rows of countries.csv from reader.
+// Iteration stops at the first invalid row, the error is stored in outErr.
+func Countries(reader io.Reader, outErr *error) iter.Seq2[int, *Country] {
+	return func(yield func(int, *Country) bool) {
+		for line, rec := range readRecords(reader, outErr) {
+			c, err := parseCountry(rec)
+			if err != nil {
+				*outErr = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+
+			if !yield(line, c) {
+				return
+			}
+		}
+	}
+}
+
+func parseAircraftType(rec csvRecord) (*AircraftType, error) {
+	engineCount, err := parseOptionalInt(rec, "engine_count")
+	if err != nil {
+		return nil, err
+	}
+
+	cargoVariant, err := parseOptionalBool(rec, "cargo_variant")
+	if err != nil {
+		return nil, err
+	}
+
+	military, err := parseOptionalBool(rec, "military")
+	if err != nil {
+		return nil, err
+	}
+
+	deprecated, err := parseOptionalBool(rec, "deprecated")
+	if err != nil {
+		return nil, err
+	}
+
+	retiredYear, err := parseOptionalInt(rec, "retired_year")
+	if err != nil {
+		return nil, err
+	}
+
+	typicalSeats, err := parseOptionalInt(rec, "typical_seats")
+	if err != nil {
+		return nil, err
+	}
+
+	maxRangeKm, err := parseOptionalInt(rec, "max_range_km")
+	if err != nil {
+		return nil, err
+	}
+
+	supersonic, err := parseOptionalBool(rec, "supersonic")
+	if err != nil {
+		return nil, err
+	}
+
+	maxSpeedMach, err := parseOptionalFloat(rec, "max_speed_mach")
+	if err != nil {
+		return nil, err
+	}
+
+	iata, icao, err := parseCodes(rec.get("iata"), rec.get("icao"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AircraftType{
+		Id:           rec.get("id"),
+		FamilyId:     rec.get("family_id"),
+		IATA:         iata,
+		ICAO:         icao,
+		WTC:          rec.get("wtc"),
+		EngineCount:  engineCount,
+		EngineType:   EngineType(rec.get("engine_type")),
+		BodyType:     BodyType(rec.get("body_type")),
+		CargoVariant: cargoVariant,
+		Military:     military,
+		TypicalSeats: typicalSeats,
+		MaxRangeKm:   maxRangeKm,
+		Supersonic:   supersonic,
+		MaxSpeedMach: maxSpeedMach,
+		SupersededBy: rec.get("superseded_by"),
+		Deprecated:   deprecated,
+		RetiredYear:  retiredYear,
+		Notes:        rec.get("notes"),
+		Name:         rec.get("name"),
+	}, nil
+}
+
+func parseAircraftFamily(rec csvRecord) (*AircraftFamily, error) {
+	iata, icao, err := parseCodes(rec.get("iata"), rec.get("icao"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AircraftFamily{
+		Id:                 rec.get("id"),
+		IATA:               iata,
+		ICAO:               icao,
+		ParentFamilyId:     rec.get("parent_family"),
+		Level:              rec.get("level"),
+		ManufacturerRegion: ManufacturerRegion(rec.get("manufacturer_region")),
+		MarketingName:      rec.get("commercial_name"),
+		Name:               rec.get("name"),
+	}, nil
+}
+
+func parseAircraftAlias(rec csvRecord) (*AircraftAlias, error) {
+	alias, err := NewIATA(rec.get("alias"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AircraftAlias{
+		Alias:            alias,
+		AircraftTypeId:   rec.get("aircraft_type"),
+		AircraftFamilyId: rec.get("aircraft_family"),
+	}, nil
+}
+
+func parseAircraftVariant(rec csvRecord) (*AircraftVariant, error) {
+	introducedYear, err := parseOptionalInt(rec, "introduced_year")
+	if err != nil {
+		return nil, err
+	}
+
+	_, icao, err := parseCodes("", rec.get("icao"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AircraftVariant{
+		Id:             rec.get("id"),
+		AircraftTypeId: rec.get("aircraft_type_id"),
+		Name:           rec.get("name"),
+		ICAO:           icao,
+		IntroducedYear: introducedYear,
+	}, nil
+}
+
+func parseAircraftPerformance(rec csvRecord) (*AircraftPerformance, error) {
+	cruiseSpeedKmh, err := parseOptionalInt(rec, "cruise_speed_kmh")
+	if err != nil {
+		return nil, err
+	}
+
+	serviceCeilingFt, err := parseOptionalInt(rec, "service_ceiling_ft")
+	if err != nil {
+		return nil, err
+	}
+
+	takeoffDistanceM, err := parseOptionalInt(rec, "takeoff_distance_m")
+	if err != nil {
+		return nil, err
+	}
+
+	landingDistanceM, err := parseOptionalInt(rec, "landing_distance_m")
+	if err != nil {
+		return nil, err
+	}
+
+	return &AircraftPerformance{
+		AircraftTypeId:   rec.get("aircraft_type_id"),
+		CruiseSpeedKmh:   cruiseSpeedKmh,
+		ServiceCeilingFt: serviceCeilingFt,
+		TakeoffDistanceM: takeoffDistanceM,
+		LandingDistanceM: landingDistanceM,
+	}, nil
+}
+
+func parseAircraftPhoto(rec csvRecord) (*AircraftPhoto, error) {
+	sortOrder, err := parseOptionalInt(rec, "sort_order")
+	if err != nil {
+		return nil, err
+	}
+
+	return &AircraftPhoto{
+		AircraftTypeId: rec.get("aircraft_type_id"),
+		SortOrder:      sortOrder,
+		URL:            rec.get("url"),
+		Attribution:    rec.get("attribution"),
+		License:        rec.get("license"),
+	}, nil
+}
+
+func parseSeatConfiguration(rec csvRecord) (*SeatConfiguration, error) {
+	firstSeats, err := parseOptionalInt(rec, "first_seats")
+	if err != nil {
+		return nil, err
+	}
+
+	businessSeats, err := parseOptionalInt(rec, "business_seats")
+	if err != nil {
+		return nil, err
+	}
+
+	premiumEconomySeats, err := parseOptionalInt(rec, "premium_economy_seats")
+	if err != nil {
+		return nil, err
+	}
+
+	economySeats, err := parseOptionalInt(rec, "economy_seats")
+	if err != nil {
+		return nil, err
+	}
+
+	return &SeatConfiguration{
+		AircraftTypeId:      rec.get("aircraft_type_id"),
+		ConfigName:          rec.get("config_name"),
+		FirstSeats:          firstSeats,
+		BusinessSeats:       businessSeats,
+		PremiumEconomySeats: premiumEconomySeats,
+		EconomySeats:        economySeats,
+	}, nil
+}
+
+func parseHistoricalAlias(rec csvRecord) (*HistoricalAlias, error) {
+	iata, err := NewIATA(rec.get("historical_iata"))
+	if err != nil {
+		return nil, err
+	}
+
+	validUntilYear, err := parseOptionalInt(rec, "valid_until_year")
+	if err != nil {
+		return nil, err
+	}
+
+	return &HistoricalAlias{
+		HistoricalIATA: iata,
+		AircraftTypeId: rec.get("aircraft_type_id"),
+		ValidUntilYear: validUntilYear,
+	}, nil
+}
+
+func parseDeprecationEntry(rec csvRecord) (*DeprecationEntry, error) {
+	oldIATA, err := NewIATA(rec.get("old_iata"))
+	if err != nil {
+		return nil, err
+	}
+
+	newIATA, err := NewIATA(rec.get("new_iata"))
+	if err != nil {
+		return nil, err
+	}
+
+	changeYear, err := parseOptionalInt(rec, "change_year")
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeprecationEntry{
+		OldIATA:        oldIATA,
+		NewIATA:        newIATA,
+		AircraftTypeId: rec.get("aircraft_type_id"),
+		ChangeYear:     changeYear,
+		Reason:         rec.get("reason"),
+	}, nil
+}
+
+func parseRegulatoryEntry(rec csvRecord) (*RegulatoryEntry, error) {
+	issueYear, err := parseOptionalInt(rec, "issue_year")
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegulatoryEntry{
+		AircraftTypeId:    rec.get("aircraft_type_id"),
+		Authority:         RegulatoryAuthority(rec.get("authority")),
+		CertificateNumber: rec.get("certificate_number"),
+		IssueYear:         issueYear,
+	}, nil
+}
+
+// parseOptionalInt parses the integer in column, returning zero if the column is empty.
+func parseOptionalInt(rec csvRecord, column string) (int, error) {
+	v := rec.get(column)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", column, v, err)
+	}
+
+	return n, nil
+}
+
+// parseOptionalFloat parses the decimal number in column, returning zero if the column is empty.
+func parseOptionalFloat(rec csvRecord, column string) (float64, error) {
+	v := rec.get(column)
+	if v == "" {
+		return 0, nil
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", column, v, err)
+	}
+
+	return f, nil
+}
+
+// parseOptionalBool parses the boolean in column, returning false if the column is empty.
+func parseOptionalBool(rec csvRecord, column string) (bool, error) {
+	v := rec.get(column)
+	if v == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: %w", column, v, err)
+	}
+
+	return b, nil
+}
+
+// parseCodes validates the optional iata and icao columns of a row.
+func parseCodes(iataValue, icaoValue string) (IATA, ICAO, error) {
+	var iata IATA
+	if iataValue != "" {
+		var err error
+		if iata, err = NewIATA(iataValue); err != nil {
+			return "", "", err
+		}
+	}
+
+	var icao ICAO
+	if icaoValue != "" {
+		var err error
+		if icao, err = NewICAO(icaoValue); err != nil {
+			return "", "", err
+		}
+	}
+
+	return iata, icao, nil
+}
+
+func parseCountry(rec csvRecord) (*Country, error) {
+	code := rec.get("code")
+	if !countryCodePattern.MatchString(code) {
+		return nil, fmt.Errorf("invalid country code %q", code)
+	}
+
+	return &Country{
+		Code:   code,
+		Name:   rec.get("name"),
+		Region: Region(rec.get("region")),
+	}, nil
+}
@@ -0,0 +1,159 @@
+package referencedata
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAircraftTypes(t *testing.T) {
+	var err error
+	var rows int
+	for range ReadCSV(strings.NewReader(types), &err) {
+		rows++
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var parsed []*AircraftType
+	for _, at := range AircraftTypes(strings.NewReader(types), &err) {
+		parsed = append(parsed, at)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(parsed) != rows {
+		t.Fatalf("expected %d aircraft types, got %d", rows, len(parsed))
+		return
+	}
+
+	for _, at := range parsed {
+		if at.Id == "" || at.Name == "" {
+			t.Fatalf("aircraft type with missing id or name: %+v", at)
+			return
+		}
+	}
+}
+
+func TestReadCsvTypedParseError(t *testing.T) {
+	var err error
+	var values []string
+	parse := func(headers, record []string) (string, error) {
+		if record[0] == "" {
+			return "", errors.New("empty id")
+		}
+
+		return record[0], nil
+	}
+
+	for _, v := range readCsvTyped(strings.NewReader("id\n320\n\"\"\n321\n"), parse, &err) {
+		values = append(values, v)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "line 2: empty id") {
+		t.Fatalf("expected parse error in line 2, got %v", err)
+		return
+	}
+
+	if len(values) != 1 || values[0] != "320" {
+		t.Fatalf("expected values before the error to be yielded, got %v", values)
+		return
+	}
+}
+
+func TestAircraftTypeNotes(t *testing.T) {
+	var err error
+	var parsed []*AircraftType
+	for _, at := range AircraftTypes(strings.NewReader("id,iata,notes,name\n320,320,\"IATA code shared with the \"\"A320ceo\"\", retired in charter\",Airbus A320\n321,321,,Airbus A321\n"), &err) {
+		parsed = append(parsed, at)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(parsed) != 2 || parsed[0].Notes != `IATA code shared with the "A320ceo", retired in charter` {
+		t.Fatalf("expected notes to be preserved, got %+v", parsed)
+		return
+	}
+
+	withNotes, err := json.Marshal(parsed[0])
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	withoutNotes, err := json.Marshal(parsed[1])
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if !strings.Contains(string(withNotes), `"notes":`) || strings.Contains(string(withoutNotes), `"notes"`) {
+		t.Fatalf("expected notes only in the json of the type with notes, got %s and %s", withNotes, withoutNotes)
+		return
+	}
+}
+
+func TestAircraftTypesBreak(t *testing.T) {
+	var err error
+	var count int
+	for range AircraftTypes(strings.NewReader(types), &err) {
+		count++
+		break
+	}
+
+	if err != nil || count != 1 {
+		t.Fatalf("expected a single iteration, got %d, %v", count, err)
+		return
+	}
+}
+
+func TestAircraftTypesInvalidRow(t *testing.T) {
+	var err error
+	var lines []int
+	for line := range AircraftTypes(strings.NewReader("id,iata,engine_count,name\n320,320,2,Airbus A320\n321,321,two,Airbus A321\n"), &err) {
+		lines = append(lines, line)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected error in line 2, got %v", err)
+		return
+	}
+
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 row before the error, got %d", len(lines))
+		return
+	}
+}
+
+func TestAircraftFamiliesAndAliases(t *testing.T) {
+	var err error
+	var families []*AircraftFamily
+	for _, f := range AircraftFamilies(strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,AIRBUS,family,Airbus A320\n"), &err) {
+		families = append(families, f)
+	}
+
+	if err != nil || len(families) != 1 || families[0].ParentFamilyId != "AIRBUS" || families[0].IATA != "32S" {
+		t.Fatalf("unexpected families: %+v, %v", families, err)
+		return
+	}
+
+	var aliases []*AircraftAlias
+	for _, a := range AircraftAliases(strings.NewReader("alias,aircraft_type,aircraft_family\n20N,32N,\n"), &err) {
+		aliases = append(aliases, a)
+	}
+
+	if err != nil || len(aliases) != 1 || aliases[0].Alias != "20N" || aliases[0].AircraftTypeId != "32N" {
+		t.Fatalf("unexpected aliases: %+v, %v", aliases, err)
+		return
+	}
+}
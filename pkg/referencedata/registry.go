@@ -0,0 +1,1413 @@
+package referencedata
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/sync/errgroup"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned by lookups when no matching record exists.
+var ErrNotFound = errors.New("not found")
+
+// ErrFieldMissing is returned when a value is derived from an optional field which is not set.
+var ErrFieldMissing = errors.New("field missing")
+
+// ErrFamilyAlias is returned by Canonicalize for codes which resolve to an aircraft family instead of an aircraft type.
+var ErrFamilyAlias = errors.New("code of an aircraft family")
+
+// BodyType is the broad category of an aircraft type.
+type BodyType string
+
+const (
+	BodyTypeWidebody    BodyType = "widebody"
+	BodyTypeNarrowbody  BodyType = "narrowbody"
+	BodyTypeRegionalJet BodyType = "regional_jet"
+	BodyTypeBusinessJet BodyType = "business_jet"
+	BodyTypeTurboprop   BodyType = "turboprop"
+	BodyTypePiston      BodyType = "piston"
+	BodyTypeHelicopter  BodyType = "helicopter"
+	BodyTypeSurface     BodyType = "surface"
+)
+
+// bodyTypes lists all known body types.
+var bodyTypes = []BodyType{
+	BodyTypeWidebody,
+	BodyTypeNarrowbody,
+	BodyTypeRegionalJet,
+	BodyTypeBusinessJet,
+	BodyTypeTurboprop,
+	BodyTypePiston,
+	BodyTypeHelicopter,
+	BodyTypeSurface,
+}
+
+// BodyTypes returns all known body types.
+func BodyTypes() []BodyType {
+	return slices.Clone(bodyTypes)
+}
+
+// EngineType is the kind of engines an aircraft type is powered by.
+type EngineType string
+
+const (
+	EngineTypeJet       EngineType = "Jet"
+	EngineTypeTurboprop EngineType = "Turboprop/Turboshaft"
+	EngineTypePiston    EngineType = "Piston"
+	EngineTypeElectric  EngineType = "Electric"
+	EngineTypeHybrid    EngineType = "Hybrid"
+)
+
+// engineTypes lists all known engine types.
+var engineTypes = []EngineType{
+	EngineTypeJet,
+	EngineTypeTurboprop,
+	EngineTypePiston,
+	EngineTypeElectric,
+	EngineTypeHybrid,
+}
+
+// EngineTypes returns all known engine types.
+func EngineTypes() []EngineType {
+	return slices.Clone(engineTypes)
+}
+
+// RangeCategory classifies aircraft types by their maximum range.
+type RangeCategory string
+
+const (
+	// RangeCategoryShortHaul is a maximum range below 3000 km.
+	RangeCategoryShortHaul RangeCategory = "short_haul"
+	// RangeCategoryMediumHaul is a maximum range of at least 3000 km and below 6000 km.
+	RangeCategoryMediumHaul RangeCategory = "medium_haul"
+	// RangeCategoryLongHaul is a maximum range of at least 6000 km and up to 12000 km.
+	RangeCategoryLongHaul RangeCategory = "long_haul"
+	// RangeCategoryUltraLongHaul is a maximum range above 12000 km.
+	RangeCategoryUltraLongHaul RangeCategory = "ultra_long_haul"
+)
+
+// rangeCategoryOf returns the range category of a maximum range of km.
+func rangeCategoryOf(km int) RangeCategory {
+	switch {
+	case km < 3000:
+		return RangeCategoryShortHaul
+	case km < 6000:
+		return RangeCategoryMediumHaul
+	case km <= 12000:
+		return RangeCategoryLongHaul
+	default:
+		return RangeCategoryUltraLongHaul
+	}
+}
+
+// wakeTurbulenceCategories lists the ICAO wake turbulence categories: light, medium, heavy and super.
+var wakeTurbulenceCategories = []string{"L", "M", "H", "J"}
+
+// ManufacturerRegion is the geographic origin of an aircraft family.
+type ManufacturerRegion string
+
+const (
+	ManufacturerRegionNorthAmerica      ManufacturerRegion = "North America"
+	ManufacturerRegionEurope            ManufacturerRegion = "Europe"
+	ManufacturerRegionBrazil            ManufacturerRegion = "Brazil"
+	ManufacturerRegionFormerSovietUnion ManufacturerRegion = "Former Soviet Union"
+	ManufacturerRegionChina             ManufacturerRegion = "China"
+	ManufacturerRegionOther             ManufacturerRegion = "Other"
+)
+
+// manufacturerRegions lists all known manufacturer regions.
+var manufacturerRegions = []ManufacturerRegion{
+	ManufacturerRegionNorthAmerica,
+	ManufacturerRegionEurope,
+	ManufacturerRegionBrazil,
+	ManufacturerRegionFormerSovietUnion,
+	ManufacturerRegionChina,
+	ManufacturerRegionOther,
+}
+
+// RegulatoryAuthority is the civil aviation authority which issued a type certificate.
+type RegulatoryAuthority string
+
+const (
+	RegulatoryAuthorityFAA   RegulatoryAuthority = "FAA"
+	RegulatoryAuthorityEASA  RegulatoryAuthority = "EASA"
+	RegulatoryAuthorityTCCA  RegulatoryAuthority = "TCCA"
+	RegulatoryAuthorityANAC  RegulatoryAuthority = "ANAC"
+	RegulatoryAuthorityCAAC  RegulatoryAuthority = "CAAC"
+	RegulatoryAuthorityOther RegulatoryAuthority = "Other"
+)
+
+// regulatoryAuthorities lists all known regulatory authorities.
+var regulatoryAuthorities = []RegulatoryAuthority{
+	RegulatoryAuthorityFAA,
+	RegulatoryAuthorityEASA,
+	RegulatoryAuthorityTCCA,
+	RegulatoryAuthorityANAC,
+	RegulatoryAuthorityCAAC,
+	RegulatoryAuthorityOther,
+}
+
+// Region is the continent a country belongs to.
+type Region string
+
+const (
+	RegionAfrica       Region = "africa"
+	RegionAntarctica   Region = "antarctica"
+	RegionAsia         Region = "asia"
+	RegionEurope       Region = "europe"
+	RegionNorthAmerica Region = "north_america"
+	RegionOceania      Region = "oceania"
+	RegionSouthAmerica Region = "south_america"
+)
+
+// regions lists all known regions.
+var regions = []Region{
+	RegionAfrica,
+	RegionAntarctica,
+	RegionAsia,
+	RegionEurope,
+	RegionNorthAmerica,
+	RegionOceania,
+	RegionSouthAmerica,
+}
+
+// AircraftType is a single row of aircraft_types.csv.
+type AircraftType struct {
+	Id           string     `json:"id"`
+	FamilyId     string     `json:"familyId,omitempty"`
+	IATA         IATA       `json:"iata"`
+	ICAO         ICAO       `json:"icao,omitempty"`
+	WTC          string     `json:"wtc,omitempty"`
+	EngineCount  int        `json:"engineCount,omitempty"`
+	EngineType   EngineType `json:"engineType,omitempty"`
+	BodyType     BodyType   `json:"bodyType,omitempty"`
+	CargoVariant bool       `json:"cargoVariant,omitempty"`
+	Military     bool       `json:"military,omitempty"`
+	TypicalSeats int        `json:"typicalSeats,omitempty"`
+	MaxRangeKm   int        `json:"maxRangeKm,omitempty"`
+	Supersonic   bool       `json:"supersonic,omitempty"`
+	MaxSpeedMach float64    `json:"maxSpeedMach,omitempty"`
+	SupersededBy string     `json:"supersededBy,omitempty"`
+	Deprecated   bool       `json:"deprecated,omitempty"`
+	RetiredYear  int        `json:"retiredYear,omitempty"`
+	Notes        string     `json:"notes,omitempty"`
+	Name         string     `json:"name"`
+}
+
+// IsSupersonic reports whether the aircraft type is capable of supersonic flight.
+func (t *AircraftType) IsSupersonic() bool {
+	return t.Supersonic
+}
+
+// ETOPS returns the likely maximum ETOPS rating in minutes of the aircraft type, derived from its maximum range.
+// Only twin jets with a known range are rated, all other aircraft types return zero.
+func (t *AircraftType) ETOPS() int {
+	if t.EngineCount != 2 || t.EngineType != EngineTypeJet || t.MaxRangeKm == 0 {
+		return 0
+	}
+
+	switch {
+	case t.MaxRangeKm < etops120MinRangeKm:
+		return etops60
+	case t.MaxRangeKm < etops180MinRangeKm:
+		return etops120
+	case t.MaxRangeKm < etops240MinRangeKm:
+		return etops180
+	case t.MaxRangeKm < etops370MinRangeKm:
+		return etops240
+	default:
+		return etops370
+	}
+}
+
+// AircraftFamily is a single row of aircraft_families.csv.
+type AircraftFamily struct {
+	Id                 string             `json:"id"`
+	IATA               IATA               `json:"iata,omitempty"`
+	ICAO               ICAO               `json:"icao,omitempty"`
+	ParentFamilyId     string             `json:"parentFamilyId,omitempty"`
+	Level              string             `json:"level"`
+	ManufacturerRegion ManufacturerRegion `json:"manufacturerRegion,omitempty"`
+	// MarketingName is the commercial_name column, see CommercialName.
+	MarketingName string `json:"commercialName,omitempty"`
+	Name          string `json:"name"`
+
+	// depth is computed once all families of a registry have been added, see Depth.
+	depth int
+}
+
+// CommercialName returns the name the family is marketed and commonly referred to by, falling back to its technical name.
+func (f *AircraftFamily) CommercialName() string {
+	if f.MarketingName != "" {
+		return f.MarketingName
+	}
+
+	return f.Name
+}
+
+// Depth returns the number of ancestors of f in the registry it was loaded into, zero for root families.
+// A cycle of parents ends the hierarchy.
+func (f *AircraftFamily) Depth() int {
+	return f.depth
+}
+
+// AircraftAlias is a single row of aircraft_aliases.csv.
+type AircraftAlias struct {
+	Alias            IATA   `json:"alias"`
+	AircraftTypeId   string `json:"aircraftTypeId,omitempty"`
+	AircraftFamilyId string `json:"aircraftFamilyId,omitempty"`
+}
+
+// AircraftVariant is a single row of aircraft_variants.csv.
+// Variants share the IATA code of their aircraft type.
+type AircraftVariant struct {
+	Id             string `json:"id"`
+	AircraftTypeId string `json:"aircraftTypeId"`
+	Name           string `json:"name"`
+	ICAO           ICAO   `json:"icao,omitempty"`
+	IntroducedYear int    `json:"introducedYear,omitempty"`
+}
+
+// SeatConfiguration is a single row of aircraft_seat_configurations.csv: a common cabin layout of an aircraft type.
+// An aircraft type can have several configurations, identified by their name.
+type SeatConfiguration struct {
+	AircraftTypeId      string `json:"aircraftTypeId"`
+	ConfigName          string `json:"configName"`
+	FirstSeats          int    `json:"firstSeats"`
+	BusinessSeats       int    `json:"businessSeats"`
+	PremiumEconomySeats int    `json:"premiumEconomySeats"`
+	EconomySeats        int    `json:"economySeats"`
+}
+
+// TotalSeats returns the number of seats of all cabin classes.
+func (c *SeatConfiguration) TotalSeats() int {
+	return c.FirstSeats + c.BusinessSeats + c.PremiumEconomySeats + c.EconomySeats
+}
+
+// AircraftPhoto is a single row of aircraft_type_photos.csv: a representative photo of an aircraft type.
+type AircraftPhoto struct {
+	AircraftTypeId string `json:"aircraftTypeId"`
+	SortOrder      int    `json:"sortOrder"`
+	URL            string `json:"url"`
+	Attribution    string `json:"attribution"`
+	// License is the SPDX identifier of the license of the photo.
+	License string `json:"license"`
+}
+
+// AircraftPerformance is a single row of aircraft_type_performance.csv. Unknown values are zero.
+type AircraftPerformance struct {
+	AircraftTypeId   string `json:"aircraftTypeId"`
+	CruiseSpeedKmh   int    `json:"cruiseSpeedKmh,omitempty"`
+	ServiceCeilingFt int    `json:"serviceCeilingFt,omitempty"`
+	TakeoffDistanceM int    `json:"takeoffDistanceM,omitempty"`
+	LandingDistanceM int    `json:"landingDistanceM,omitempty"`
+}
+
+// HistoricalAlias is a single row of historical_aliases.csv: an IATA code formerly used for an aircraft type.
+type HistoricalAlias struct {
+	HistoricalIATA IATA   `json:"historicalIata"`
+	AircraftTypeId string `json:"aircraftTypeId"`
+	ValidUntilYear int    `json:"validUntilYear,omitempty"`
+}
+
+// ExternalAircraftTypeId is the aircraft type id of DeprecationEntry rows whose aircraft type is not part of aircraft_types.csv.
+const ExternalAircraftTypeId = "external"
+
+// DeprecationEntry is a single row of aircraft_types_deprecation_log.csv: the reassignment of an IATA code.
+type DeprecationEntry struct {
+	OldIATA IATA `json:"oldIata"`
+	NewIATA IATA `json:"newIata"`
+	// AircraftTypeId is the aircraft type affected by the change or ExternalAircraftTypeId.
+	AircraftTypeId string `json:"aircraftTypeId"`
+	ChangeYear     int    `json:"changeYear"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// RegulatoryEntry is a single row of aircraft_regulatory.csv: a type certificate covering an aircraft type.
+type RegulatoryEntry struct {
+	AircraftTypeId    string              `json:"aircraftTypeId"`
+	Authority         RegulatoryAuthority `json:"authority"`
+	CertificateNumber string              `json:"certificateNumber"`
+	IssueYear         int                 `json:"issueYear"`
+}
+
+// AircraftTypeName is a single row of aircraft_type_names.csv: the name of an aircraft type in a language.
+type AircraftTypeName struct {
+	AircraftTypeId string `json:"aircraftTypeId"`
+	Locale         string `json:"locale"`
+	Name           string `json:"name"`
+}
+
+// Country is a single row of countries.csv.
+type Country struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Region Region `json:"region"`
+}
+
+// LookupResult is the target an IATA code resolves to.
+// Exactly one of Type and Family is set.
+// Historical is set if the code is no longer in use and was resolved through a historical alias.
+// Deprecated is set if the supersession chain of the resolved aircraft type was followed to its current successor.
+type LookupResult struct {
+	Type       *AircraftType   `json:"type,omitempty"`
+	Family     *AircraftFamily `json:"family,omitempty"`
+	Historical bool            `json:"historical,omitempty"`
+	Deprecated bool            `json:"deprecated,omitempty"`
+}
+
+// LookupOption changes the behavior of LookupByIATA.
+type LookupOption func(*lookupOptions)
+
+type lookupOptions struct {
+	followSuperseded bool
+}
+
+// FollowSuperseded makes LookupByIATA return the current successor of a superseded aircraft type.
+func FollowSuperseded() LookupOption {
+	return func(o *lookupOptions) {
+		o.followSuperseded = true
+	}
+}
+
+// Registry holds the parsed reference data.
+type Registry struct {
+	types              []*AircraftType
+	families           []*AircraftFamily
+	aliases            []*AircraftAlias
+	typeById           map[string]*AircraftType
+	typeByIATA         map[IATA]*AircraftType
+	typesByICAO        map[ICAO][]*AircraftType
+	typesByFamilyId    map[string][]*AircraftType
+	familyById         map[string]*AircraftFamily
+	familyByIATA       map[IATA]*AircraftFamily
+	familyByICAO       map[ICAO]*AircraftFamily
+	familiesByParentId map[string][]*AircraftFamily
+	aliasByAlias       map[IATA]*AircraftAlias
+	aliasesByTypeId    map[string][]*AircraftAlias
+	aliasesByFamilyId  map[string][]*AircraftAlias
+	variants           []*AircraftVariant
+	variantsByTypeId   map[string][]*AircraftVariant
+	seatConfigurations []*SeatConfiguration
+	seatConfigsByType  map[string][]*SeatConfiguration
+	photos             []*AircraftPhoto
+	photosByTypeId     map[string][]*AircraftPhoto
+	performance        []*AircraftPerformance
+	deprecationLog     []*DeprecationEntry
+	regulatory         []*RegulatoryEntry
+	regulatoryByTypeId map[string][]*RegulatoryEntry
+	performanceById    map[string]*AircraftPerformance
+	historicalAliases  []*HistoricalAlias
+	historicalByIATA   map[IATA]*HistoricalAlias
+	typeNames          []*AircraftTypeName
+	namesByTypeId      map[string]map[string]string
+	countries          []*Country
+	countryByCode      map[string]*Country
+	statsOnce          sync.Once
+	stats              *RegistryStats
+	similarityWeights  SimilarityWeights
+}
+
+// RegistryOption changes the configuration of a Registry at construction time.
+type RegistryOption func(*Registry)
+
+// NewRegistry builds a Registry from the embedded CSV files.
+func NewRegistry(opts ...RegistryOption) (*Registry, error) {
+	r, err := newRegistry(strings.NewReader(types), strings.NewReader(families), strings.NewReader(aliases))
+	if err != nil {
+		return nil, err
+	}
+
+	r.apply(opts)
+	return r, r.loadEmbeddedSupplements()
+}
+
+// NewRegistryFromPaths builds a Registry from CSV files on the filesystem.
+// An empty path falls back to the embedded file.
+func NewRegistryFromPaths(typesPath, familiesPath, aliasesPath string, opts ...RegistryOption) (*Registry, error) {
+	typesReader, err := openOrEmbedded(typesPath, types)
+	if err != nil {
+		return nil, err
+	}
+	defer typesReader.Close()
+
+	familiesReader, err := openOrEmbedded(familiesPath, families)
+	if err != nil {
+		return nil, err
+	}
+	defer familiesReader.Close()
+
+	aliasesReader, err := openOrEmbedded(aliasesPath, aliases)
+	if err != nil {
+		return nil, err
+	}
+	defer aliasesReader.Close()
+
+	r, err := newRegistry(typesReader, familiesReader, aliasesReader)
+	if err != nil {
+		return nil, err
+	}
+
+	r.apply(opts)
+	return r, r.loadEmbeddedSupplements()
+}
+
+// NewRegistryFromDir builds a Registry from aircraft_types.csv, aircraft_families.csv and aircraft_aliases.csv in dir.
+func NewRegistryFromDir(dir string, opts ...RegistryOption) (*Registry, error) {
+	return NewRegistryFromPaths(
+		filepath.Join(dir, "aircraft_types.csv"),
+		filepath.Join(dir, "aircraft_families.csv"),
+		filepath.Join(dir, "aircraft_aliases.csv"),
+		opts...,
+	)
+}
+
+// NewRegistryFromReaders builds a Registry from the contents of aircraft_types.csv, aircraft_families.csv and aircraft_aliases.csv.
+// Unlike the other constructors it does not load the supplementary embedded files such as variants and localized names.
+func NewRegistryFromReaders(typesReader, familiesReader, aliasesReader io.Reader, opts ...RegistryOption) (*Registry, error) {
+	r, err := newRegistry(typesReader, familiesReader, aliasesReader)
+	if err != nil {
+		return nil, err
+	}
+
+	r.apply(opts)
+	return r, nil
+}
+
+func (r *Registry) apply(opts []RegistryOption) {
+	for _, opt := range opts {
+		opt(r)
+	}
+}
+
+func openOrEmbedded(path, embedded string) (io.ReadCloser, error) {
+	if path == "" {
+		return io.NopCloser(strings.NewReader(embedded)), nil
+	}
+
+	return os.Open(path)
+}
+
+// newRegistry parses the three readers concurrently and builds the indices once all of them have been parsed.
+func newRegistry(typesReader, familiesReader, aliasesReader io.Reader) (*Registry, error) {
+	var aircraftTypes []*AircraftType
+	var aircraftFamilies []*AircraftFamily
+	var aircraftAliases []*AircraftAlias
+
+	var g errgroup.Group
+	g.Go(func() error {
+		var err error
+		if aircraftTypes, err = readAll(AircraftTypes, typesReader); err != nil {
+			return fmt.Errorf("failed to read aircraft types: %w", err)
+		}
+
+		return nil
+	})
+
+	g.Go(func() error {
+		var err error
+		if aircraftFamilies, err = readAll(AircraftFamilies, familiesReader); err != nil {
+			return fmt.Errorf("failed to read aircraft families: %w", err)
+		}
+
+		return nil
+	})
+
+	g.Go(func() error {
+		var err error
+		if aircraftAliases, err = readAll(AircraftAliases, aliasesReader); err != nil {
+			return fmt.Errorf("failed to read aircraft aliases: %w", err)
+		}
+
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	r := newEmptyRegistry()
+	for _, t := range aircraftTypes {
+		r.addType(t)
+	}
+
+	for _, f := range aircraftFamilies {
+		r.addFamily(f)
+	}
+
+	for _, a := range aircraftAliases {
+		r.addAlias(a)
+	}
+
+	r.computeFamilyDepths()
+	return r, nil
+}
+
+// readAll collects all records yielded by records for reader.
+func readAll[T any](records func(io.Reader, *error) iter.Seq2[int, T], reader io.Reader) ([]T, error) {
+	var err error
+	var res []T
+	for _, v := range records(reader, &err) {
+		res = append(res, v)
+	}
+
+	return res, err
+}
+
+// computeFamilyDepths caches the depth of every family of r. It must be called once all families have been added.
+func (r *Registry) computeFamilyDepths() {
+	for _, f := range r.families {
+		f.depth = 0
+		visited := map[string]struct{}{f.Id: {}}
+		for parent, ok := r.familyById[f.ParentFamilyId]; ok; parent, ok = r.familyById[parent.ParentFamilyId] {
+			if _, ok := visited[parent.Id]; ok {
+				break
+			}
+
+			visited[parent.Id] = struct{}{}
+			f.depth++
+		}
+	}
+}
+
+func newEmptyRegistry() *Registry {
+	return &Registry{
+		typeById:           make(map[string]*AircraftType),
+		typeByIATA:         make(map[IATA]*AircraftType),
+		typesByICAO:        make(map[ICAO][]*AircraftType),
+		typesByFamilyId:    make(map[string][]*AircraftType),
+		familyById:         make(map[string]*AircraftFamily),
+		familyByIATA:       make(map[IATA]*AircraftFamily),
+		familyByICAO:       make(map[ICAO]*AircraftFamily),
+		familiesByParentId: make(map[string][]*AircraftFamily),
+		aliasByAlias:       make(map[IATA]*AircraftAlias),
+		aliasesByTypeId:    make(map[string][]*AircraftAlias),
+		aliasesByFamilyId:  make(map[string][]*AircraftAlias),
+		variantsByTypeId:   make(map[string][]*AircraftVariant),
+		seatConfigsByType:  make(map[string][]*SeatConfiguration),
+		photosByTypeId:     make(map[string][]*AircraftPhoto),
+		regulatoryByTypeId: make(map[string][]*RegulatoryEntry),
+		performanceById:    make(map[string]*AircraftPerformance),
+		historicalByIATA:   make(map[IATA]*HistoricalAlias),
+		namesByTypeId:      make(map[string]map[string]string),
+		countryByCode:      make(map[string]*Country),
+		similarityWeights:  DefaultSimilarityWeights,
+	}
+}
+
+func (r *Registry) addType(t *AircraftType) {
+	r.types = append(r.types, t)
+	r.typeById[t.Id] = t
+	r.typeByIATA[t.IATA] = t
+	if t.ICAO != "" {
+		r.typesByICAO[t.ICAO] = append(r.typesByICAO[t.ICAO], t)
+	}
+
+	if t.FamilyId != "" {
+		r.typesByFamilyId[t.FamilyId] = append(r.typesByFamilyId[t.FamilyId], t)
+	}
+}
+
+func (r *Registry) addFamily(f *AircraftFamily) {
+	r.families = append(r.families, f)
+	r.familyById[f.Id] = f
+	if f.IATA != "" {
+		r.familyByIATA[f.IATA] = f
+	}
+
+	if f.ICAO != "" {
+		r.familyByICAO[f.ICAO] = f
+	}
+
+	if f.ParentFamilyId != "" {
+		r.familiesByParentId[f.ParentFamilyId] = append(r.familiesByParentId[f.ParentFamilyId], f)
+	}
+}
+
+func (r *Registry) addAlias(a *AircraftAlias) {
+	r.aliases = append(r.aliases, a)
+	r.aliasByAlias[a.Alias] = a
+	if a.AircraftTypeId != "" {
+		r.aliasesByTypeId[a.AircraftTypeId] = append(r.aliasesByTypeId[a.AircraftTypeId], a)
+	} else if a.AircraftFamilyId != "" {
+		r.aliasesByFamilyId[a.AircraftFamilyId] = append(r.aliasesByFamilyId[a.AircraftFamilyId], a)
+	}
+}
+
+func (r *Registry) addVariant(v *AircraftVariant) {
+	r.variants = append(r.variants, v)
+	r.variantsByTypeId[v.AircraftTypeId] = append(r.variantsByTypeId[v.AircraftTypeId], v)
+}
+
+func (r *Registry) addDeprecationEntry(e *DeprecationEntry) {
+	r.deprecationLog = append(r.deprecationLog, e)
+}
+
+func (r *Registry) addRegulatoryEntry(e *RegulatoryEntry) {
+	r.regulatory = append(r.regulatory, e)
+	r.regulatoryByTypeId[e.AircraftTypeId] = append(r.regulatoryByTypeId[e.AircraftTypeId], e)
+}
+
+func (r *Registry) addPerformance(p *AircraftPerformance) {
+	r.performance = append(r.performance, p)
+	r.performanceById[p.AircraftTypeId] = p
+}
+
+func (r *Registry) addPhoto(p *AircraftPhoto) {
+	r.photos = append(r.photos, p)
+	r.photosByTypeId[p.AircraftTypeId] = append(r.photosByTypeId[p.AircraftTypeId], p)
+}
+
+func (r *Registry) addSeatConfiguration(c *SeatConfiguration) {
+	r.seatConfigurations = append(r.seatConfigurations, c)
+	r.seatConfigsByType[c.AircraftTypeId] = append(r.seatConfigsByType[c.AircraftTypeId], c)
+}
+
+func (r *Registry) addHistoricalAlias(h *HistoricalAlias) {
+	r.historicalAliases = append(r.historicalAliases, h)
+	r.historicalByIATA[h.HistoricalIATA] = h
+}
+
+func (r *Registry) addTypeName(n *AircraftTypeName) {
+	r.typeNames = append(r.typeNames, n)
+
+	names, ok := r.namesByTypeId[n.AircraftTypeId]
+	if !ok {
+		names = make(map[string]string)
+		r.namesByTypeId[n.AircraftTypeId] = names
+	}
+
+	names[strings.ToLower(n.Locale)] = n.Name
+}
+
+func (r *Registry) addCountry(c *Country) {
+	r.countries = append(r.countries, c)
+	r.countryByCode[c.Code] = c
+}
+
+// loadEmbeddedSupplements loads the embedded files which complement the aircraft types.
+func (r *Registry) loadEmbeddedSupplements() error {
+	if err := r.loadVariants(strings.NewReader(variants)); err != nil {
+		return err
+	}
+
+	if err := r.loadSeatConfigurations(strings.NewReader(seatConfigurations)); err != nil {
+		return err
+	}
+
+	if err := r.loadPhotos(strings.NewReader(photos)); err != nil {
+		return err
+	}
+
+	if err := r.loadDeprecationLog(strings.NewReader(deprecationLog)); err != nil {
+		return err
+	}
+
+	if err := r.loadRegulatory(strings.NewReader(regulatory)); err != nil {
+		return err
+	}
+
+	if err := r.loadPerformance(strings.NewReader(performance)); err != nil {
+		return err
+	}
+
+	if err := r.loadHistoricalAliases(strings.NewReader(historicalAliases)); err != nil {
+		return err
+	}
+
+	if err := r.loadNames(strings.NewReader(typeNames)); err != nil {
+		return err
+	}
+
+	return r.loadCountries(strings.NewReader(countries))
+}
+
+// loadVariants adds the aircraft variants read from reader.
+func (r *Registry) loadVariants(reader io.Reader) error {
+	var err error
+	for _, v := range AircraftVariants(reader, &err) {
+		r.addVariant(v)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read aircraft variants: %w", err)
+	}
+
+	return nil
+}
+
+// loadSeatConfigurations adds the seat configurations read from reader.
+func (r *Registry) loadSeatConfigurations(reader io.Reader) error {
+	var err error
+	for _, c := range SeatConfigurations(reader, &err) {
+		r.addSeatConfiguration(c)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read seat configurations: %w", err)
+	}
+
+	return nil
+}
+
+// loadDeprecationLog adds the IATA code reassignments read from reader.
+func (r *Registry) loadDeprecationLog(reader io.Reader) error {
+	var err error
+	for _, e := range DeprecationEntries(reader, &err) {
+		r.addDeprecationEntry(e)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read deprecation log: %w", err)
+	}
+
+	return nil
+}
+
+// loadRegulatory adds the type certificates read from reader.
+func (r *Registry) loadRegulatory(reader io.Reader) error {
+	var err error
+	for _, e := range RegulatoryEntries(reader, &err) {
+		r.addRegulatoryEntry(e)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read regulatory certificates: %w", err)
+	}
+
+	return nil
+}
+
+// loadPerformance adds the aircraft performance records read from reader.
+func (r *Registry) loadPerformance(reader io.Reader) error {
+	var err error
+	for _, p := range AircraftPerformances(reader, &err) {
+		r.addPerformance(p)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read aircraft performance: %w", err)
+	}
+
+	return nil
+}
+
+// loadPhotos adds the aircraft photos read from reader.
+func (r *Registry) loadPhotos(reader io.Reader) error {
+	var err error
+	for _, p := range AircraftPhotos(reader, &err) {
+		r.addPhoto(p)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read aircraft photos: %w", err)
+	}
+
+	return nil
+}
+
+// loadHistoricalAliases adds the historical aliases read from reader.
+func (r *Registry) loadHistoricalAliases(reader io.Reader) error {
+	var err error
+	for _, h := range HistoricalAliases(reader, &err) {
+		r.addHistoricalAlias(h)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read historical aliases: %w", err)
+	}
+
+	return nil
+}
+
+// loadNames adds the localized aircraft type names read from reader.
+func (r *Registry) loadNames(reader io.Reader) error {
+	var err error
+	for _, n := range AircraftTypeNames(reader, &err) {
+		r.addTypeName(n)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read aircraft type names: %w", err)
+	}
+
+	return nil
+}
+
+// loadCountries adds the countries read from reader.
+func (r *Registry) loadCountries(reader io.Reader) error {
+	var err error
+	for _, c := range Countries(reader, &err) {
+		r.addCountry(c)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read countries: %w", err)
+	}
+
+	return nil
+}
+
+// Types returns all aircraft types in file order.
+func (r *Registry) Types() []*AircraftType {
+	return r.types
+}
+
+// Families returns all aircraft families in file order.
+func (r *Registry) Families() []*AircraftFamily {
+	return r.families
+}
+
+// Aliases returns all aircraft aliases in file order.
+func (r *Registry) Aliases() []*AircraftAlias {
+	return r.aliases
+}
+
+// Variants returns all aircraft variants in file order.
+func (r *Registry) Variants() []*AircraftVariant {
+	return r.variants
+}
+
+// TypeVariants returns the variants of the aircraft type with the given id.
+func (r *Registry) TypeVariants(id string) []*AircraftVariant {
+	return r.variantsByTypeId[id]
+}
+
+// DeprecationEntries returns all IATA code reassignments in file order.
+func (r *Registry) DeprecationEntries() []*DeprecationEntry {
+	return r.deprecationLog
+}
+
+// DeprecationLog returns the reassignments from or to the given IATA code in file order.
+func (r *Registry) DeprecationLog(iata IATA) []*DeprecationEntry {
+	var res []*DeprecationEntry
+	for _, e := range r.deprecationLog {
+		if e.OldIATA == iata || e.NewIATA == iata {
+			res = append(res, e)
+		}
+	}
+
+	return res
+}
+
+// RegulatoryEntries returns all type certificates in file order.
+func (r *Registry) RegulatoryEntries() []*RegulatoryEntry {
+	return r.regulatory
+}
+
+// RegulatoryCertificates returns the type certificates covering the aircraft type with the given id in file order.
+func (r *Registry) RegulatoryCertificates(typeId string) []*RegulatoryEntry {
+	return r.regulatoryByTypeId[typeId]
+}
+
+// Performances returns all aircraft performance records in file order.
+func (r *Registry) Performances() []*AircraftPerformance {
+	return r.performance
+}
+
+// Performance returns the performance record of the aircraft type with the given id.
+func (r *Registry) Performance(typeId string) (*AircraftPerformance, error) {
+	p, ok := r.performanceById[typeId]
+	if !ok {
+		return nil, fmt.Errorf("performance of aircraft type %q: %w", typeId, ErrNotFound)
+	}
+
+	return p, nil
+}
+
+// Photos returns all aircraft photos in file order.
+func (r *Registry) Photos() []*AircraftPhoto {
+	return r.photos
+}
+
+// PrimaryPhoto returns the photo with the lowest sort order of the aircraft type with the given id.
+// Photos with the same sort order are in file order.
+func (r *Registry) PrimaryPhoto(typeId string) (*AircraftPhoto, error) {
+	photos := r.photosByTypeId[typeId]
+	if len(photos) == 0 {
+		return nil, fmt.Errorf("photo of aircraft type %q: %w", typeId, ErrNotFound)
+	}
+
+	primary := photos[0]
+	for _, p := range photos[1:] {
+		if p.SortOrder < primary.SortOrder {
+			primary = p
+		}
+	}
+
+	return primary, nil
+}
+
+// SeatConfigurations returns all seat configurations in file order.
+func (r *Registry) SeatConfigurations() []*SeatConfiguration {
+	return r.seatConfigurations
+}
+
+// ConfigurationsFor returns the seat configurations of the aircraft type with the given id in file order.
+func (r *Registry) ConfigurationsFor(typeId string) []*SeatConfiguration {
+	return r.seatConfigsByType[typeId]
+}
+
+// HistoricalAliases returns all historical aliases in file order.
+func (r *Registry) HistoricalAliases() []*HistoricalAlias {
+	return r.historicalAliases
+}
+
+// ByEngineType returns all aircraft types with the given engine type in file order.
+func (r *Registry) ByEngineType(et EngineType) []*AircraftType {
+	var res []*AircraftType
+	for _, t := range r.types {
+		if t.EngineType == et {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
+
+// ByWTC returns all aircraft types with the given wake turbulence category in file order.
+func (r *Registry) ByWTC(cat string) []*AircraftType {
+	var res []*AircraftType
+	for _, t := range r.types {
+		if t.WTC == cat {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
+
+// ByManufacturerRegion returns all aircraft families of the given manufacturer region in file order.
+func (r *Registry) ByManufacturerRegion(region ManufacturerRegion) []*AircraftFamily {
+	var res []*AircraftFamily
+	for _, f := range r.families {
+		if f.ManufacturerRegion == region {
+			res = append(res, f)
+		}
+	}
+
+	return res
+}
+
+// ByCategory returns all aircraft types in one of the selected categories in file order.
+// Each aircraft type belongs to exactly one category: military if it is a military type,
+// otherwise cargo if it is a cargo variant, and civil passenger otherwise.
+// ByCategory(true, false, false) therefore returns only commercial passenger types.
+func (r *Registry) ByCategory(civil, cargo, military bool) []*AircraftType {
+	var res []*AircraftType
+	for _, t := range r.types {
+		var include bool
+		switch {
+		case t.Military:
+			include = military
+		case t.CargoVariant:
+			include = cargo
+		default:
+			include = civil
+		}
+
+		if include {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
+
+// FindLargerThan returns all aircraft types with more typical seats than seats in file order.
+// Aircraft types without a known seat count are never included.
+func (r *Registry) FindLargerThan(seats int) []*AircraftType {
+	var res []*AircraftType
+	for _, t := range r.types {
+		if t.TypicalSeats > seats {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
+
+// FlightRangeCategory returns the range category of the aircraft type with the given id based on its maximum range.
+// ErrFieldMissing is returned if the maximum range of the aircraft type is unknown.
+func (r *Registry) FlightRangeCategory(typeId string) (RangeCategory, error) {
+	t, ok := r.typeById[typeId]
+	if !ok {
+		return "", fmt.Errorf("aircraft type %q: %w", typeId, ErrNotFound)
+	} else if t.MaxRangeKm == 0 {
+		return "", fmt.Errorf("max range of aircraft type %q: %w", typeId, ErrFieldMissing)
+	}
+
+	return rangeCategoryOf(t.MaxRangeKm), nil
+}
+
+// AircraftFilter selects aircraft types by their characteristics. Nil fields match every aircraft type.
+type AircraftFilter struct {
+	BodyType    *BodyType
+	EngineType  *EngineType
+	EngineCount *int
+	// MinSeats and MaxSeats are inclusive bounds of the typical seats.
+	// Aircraft types without a known seat count never match if either is set.
+	MinSeats *int
+	MaxSeats *int
+	// ActiveOnly excludes deprecated aircraft types.
+	ActiveOnly bool
+}
+
+func (f AircraftFilter) matches(t *AircraftType) bool {
+	switch {
+	case f.BodyType != nil && t.BodyType != *f.BodyType:
+		return false
+	case f.EngineType != nil && t.EngineType != *f.EngineType:
+		return false
+	case f.EngineCount != nil && t.EngineCount != *f.EngineCount:
+		return false
+	case (f.MinSeats != nil || f.MaxSeats != nil) && t.TypicalSeats == 0:
+		return false
+	case f.MinSeats != nil && t.TypicalSeats < *f.MinSeats:
+		return false
+	case f.MaxSeats != nil && t.TypicalSeats > *f.MaxSeats:
+		return false
+	case f.ActiveOnly && t.Deprecated:
+		return false
+	}
+
+	return true
+}
+
+// SearchByCharacteristics returns all aircraft types matching every set field of f in file order.
+func (r *Registry) SearchByCharacteristics(f AircraftFilter) []*AircraftType {
+	var res []*AircraftType
+	for _, t := range r.types {
+		if f.matches(t) {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
+
+// LocalizedName returns the name of the aircraft type with the given id in the best matching locale.
+// Like Accept-Language matching, subtags are removed from the end of locale until a name is found,
+// e.g. zh-Hans-CN, zh-Hans, zh. If there is none, the same is tried for fallback, usually en.
+// Without any localized name the name from aircraft_types.csv is returned,
+// or the empty string if there is no aircraft type with the given id.
+func (r *Registry) LocalizedName(id, locale, fallback string) string {
+	t, ok := r.typeById[id]
+	if !ok {
+		return ""
+	}
+
+	names := r.namesByTypeId[id]
+	for _, tag := range []string{locale, fallback} {
+		for tag = strings.ToLower(tag); tag != ""; {
+			if name, ok := names[tag]; ok {
+				return name
+			}
+
+			i := strings.LastIndexByte(tag, '-')
+			if i < 0 {
+				break
+			}
+
+			tag = tag[:i]
+		}
+	}
+
+	return t.Name
+}
+
+// TypeNames returns all localized aircraft type names in file order.
+func (r *Registry) TypeNames() []*AircraftTypeName {
+	return r.typeNames
+}
+
+// Countries returns all countries in file order.
+func (r *Registry) Countries() []*Country {
+	return r.countries
+}
+
+// CountryByCode returns the country with the given ISO 3166-1 alpha-2 code.
+func (r *Registry) CountryByCode(code string) (*Country, error) {
+	c, ok := r.countryByCode[code]
+	if !ok {
+		return nil, fmt.Errorf("country %q: %w", code, ErrNotFound)
+	}
+
+	return c, nil
+}
+
+// Type returns the aircraft type with the given id.
+func (r *Registry) Type(id string) (*AircraftType, bool) {
+	t, ok := r.typeById[id]
+	return t, ok
+}
+
+// Family returns the aircraft family with the given id.
+func (r *Registry) Family(id string) (*AircraftFamily, bool) {
+	f, ok := r.familyById[id]
+	return f, ok
+}
+
+// Manufacturer returns the closest ancestor family of the aircraft type whose level is manufacturer.
+func (r *Registry) Manufacturer(t *AircraftType) (*AircraftFamily, bool) {
+	visited := make(map[string]struct{})
+	for familyId := t.FamilyId; familyId != ""; {
+		if _, ok := visited[familyId]; ok {
+			break
+		}
+
+		f, ok := r.familyById[familyId]
+		if !ok {
+			break
+		} else if f.Level == "manufacturer" {
+			return f, true
+		}
+
+		visited[familyId] = struct{}{}
+		familyId = f.ParentFamilyId
+	}
+
+	return nil, false
+}
+
+// FamilyChildren returns the direct sub families and aircraft types of the family with the given id.
+func (r *Registry) FamilyChildren(id string) ([]*AircraftFamily, []*AircraftType, error) {
+	if _, ok := r.familyById[id]; !ok {
+		return nil, nil, fmt.Errorf("family %q: %w", id, ErrNotFound)
+	}
+
+	return r.familiesByParentId[id], r.typesByFamilyId[id], nil
+}
+
+// FamilyDescendants returns all families below the family with the given id
+// and all aircraft types belonging to the family itself or any of its descendants.
+func (r *Registry) FamilyDescendants(id string) ([]*AircraftFamily, []*AircraftType, error) {
+	if _, ok := r.familyById[id]; !ok {
+		return nil, nil, fmt.Errorf("family %q: %w", id, ErrNotFound)
+	}
+
+	var descendants []*AircraftFamily
+	aircraftTypes := slices.Clone(r.typesByFamilyId[id])
+	visited := map[string]struct{}{id: {}}
+	queue := []string{id}
+
+	for len(queue) > 0 {
+		familyId := queue[0]
+		queue = queue[1:]
+
+		for _, f := range r.familiesByParentId[familyId] {
+			if _, ok := visited[f.Id]; ok {
+				continue
+			}
+
+			visited[f.Id] = struct{}{}
+			descendants = append(descendants, f)
+			aircraftTypes = append(aircraftTypes, r.typesByFamilyId[f.Id]...)
+			queue = append(queue, f.Id)
+		}
+	}
+
+	return descendants, aircraftTypes, nil
+}
+
+// EnumerateRoots returns all families without a parent family sorted by name.
+func (r *Registry) EnumerateRoots() []*AircraftFamily {
+	roots := make([]*AircraftFamily, 0)
+	for _, f := range r.families {
+		if f.ParentFamilyId == "" {
+			roots = append(roots, f)
+		}
+	}
+
+	slices.SortStableFunc(roots, func(a, b *AircraftFamily) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return roots
+}
+
+// EnumerateLeafTypes returns all aircraft types in file order which either have no family
+// or whose family has no sub families.
+func (r *Registry) EnumerateLeafTypes() []*AircraftType {
+	leafTypes := make([]*AircraftType, 0)
+	for _, t := range r.types {
+		if t.FamilyId == "" || len(r.familiesByParentId[t.FamilyId]) == 0 {
+			leafTypes = append(leafTypes, t)
+		}
+	}
+
+	return leafTypes
+}
+
+// SiblingTypes returns all other aircraft types of the direct family of the aircraft type with the given id.
+// Aircraft types without a family have no siblings.
+func (r *Registry) SiblingTypes(id string) ([]*AircraftType, error) {
+	t, ok := r.typeById[id]
+	if !ok {
+		return nil, fmt.Errorf("aircraft type %q: %w", id, ErrNotFound)
+	}
+
+	siblings := make([]*AircraftType, 0)
+	if t.FamilyId == "" {
+		return siblings, nil
+	}
+
+	for _, sibling := range r.typesByFamilyId[t.FamilyId] {
+		if sibling.Id != id {
+			siblings = append(siblings, sibling)
+		}
+	}
+
+	return siblings, nil
+}
+
+// LookupByICAO returns all aircraft types with the given ICAO designator.
+// If no aircraft type matches, the family with that ICAO code is returned instead.
+func (r *Registry) LookupByICAO(icao ICAO) ([]*AircraftType, *AircraftFamily, error) {
+	if matches, ok := r.typesByICAO[icao]; ok {
+		return matches, nil, nil
+	}
+
+	if f, ok := r.familyByICAO[icao]; ok {
+		return nil, f, nil
+	}
+
+	return nil, nil, fmt.Errorf("icao %q: %w", icao, ErrNotFound)
+}
+
+// LookupByIATA resolves an IATA code to an aircraft type or family.
+// Codes of aircraft types take precedence over codes of families, aliases are followed to their target.
+// Historical aliases are only considered if the code is not in use otherwise.
+func (r *Registry) LookupByIATA(iata IATA, opts ...LookupOption) (LookupResult, error) {
+	var o lookupOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	res, err := r.lookupByIATA(iata)
+	if err != nil || !o.followSuperseded || res.Type == nil {
+		return res, err
+	}
+
+	visited := map[string]struct{}{res.Type.Id: {}}
+	for res.Type.SupersededBy != "" {
+		t, ok := r.typeById[res.Type.SupersededBy]
+		if !ok {
+			break
+		} else if _, ok := visited[t.Id]; ok {
+			break
+		}
+
+		visited[t.Id] = struct{}{}
+		res.Type = t
+		res.Deprecated = true
+	}
+
+	return res, nil
+}
+
+func (r *Registry) lookupByIATA(iata IATA) (LookupResult, error) {
+	if res, ok := r.resolveIATA(iata); ok {
+		return res, nil
+	}
+
+	return LookupResult{}, fmt.Errorf("iata %q: %w", iata, ErrNotFound)
+}
+
+// resolveIATA is lookupByIATA without allocating an error for unknown codes.
+func (r *Registry) resolveIATA(iata IATA) (LookupResult, bool) {
+	if t, ok := r.typeByIATA[iata]; ok {
+		return LookupResult{Type: t}, true
+	}
+
+	if f, ok := r.familyByIATA[iata]; ok {
+		return LookupResult{Family: f}, true
+	}
+
+	if a, ok := r.aliasByAlias[iata]; ok {
+		if t, ok := r.typeById[a.AircraftTypeId]; ok {
+			return LookupResult{Type: t}, true
+		}
+
+		if f, ok := r.familyById[a.AircraftFamilyId]; ok {
+			return LookupResult{Family: f}, true
+		}
+	}
+
+	if h, ok := r.historicalByIATA[iata]; ok {
+		if t, ok := r.typeById[h.AircraftTypeId]; ok {
+			return LookupResult{Type: t, Historical: true}, true
+		}
+	}
+
+	return LookupResult{}, false
+}
+
+// BatchLookupByIATA resolves every code like LookupByIATA without options and returns the aircraft types by code.
+// Codes which are unknown or resolve to a family are returned in notFound, each once and in the order of codes.
+func (r *Registry) BatchLookupByIATA(codes []string) (found map[string]*AircraftType, notFound []string) {
+	found = make(map[string]*AircraftType, len(codes))
+	var missing map[string]struct{}
+	for _, code := range codes {
+		if res, ok := r.resolveIATA(IATA(code)); ok && res.Type != nil {
+			found[code] = res.Type
+		} else if _, ok := missing[code]; !ok {
+			if missing == nil {
+				missing = make(map[string]struct{})
+			}
+
+			missing[code] = struct{}{}
+			notFound = append(notFound, code)
+		}
+	}
+
+	return found, notFound
+}
+
+// Canonicalize resolves an IATA code like LookupByIATA without options and returns the IATA code of the aircraft type.
+// Codes which resolve to an aircraft family return ErrFamilyAlias.
+func (r *Registry) Canonicalize(iata string) (string, error) {
+	res, err := r.lookupByIATA(IATA(iata))
+	if err != nil {
+		return "", err
+	} else if res.Type == nil {
+		return "", fmt.Errorf("iata %q: %w", iata, ErrFamilyAlias)
+	}
+
+	return string(res.Type.IATA), nil
+}
+
+// AllAliasesFor returns all IATA codes which resolve to the same aircraft type or family as the given code,
+// including the code of the target itself. The result is sorted.
+func (r *Registry) AllAliasesFor(iata IATA) ([]IATA, error) {
+	res, err := r.LookupByIATA(iata)
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []IATA
+	var aliases []*AircraftAlias
+	if res.Type != nil {
+		codes = append(codes, res.Type.IATA)
+		aliases = r.aliasesByTypeId[res.Type.Id]
+	} else {
+		if res.Family.IATA != "" {
+			codes = append(codes, res.Family.IATA)
+		}
+
+		aliases = r.aliasesByFamilyId[res.Family.Id]
+	}
+
+	for _, a := range aliases {
+		codes = append(codes, a.Alias)
+	}
+
+	slices.Sort(codes)
+	return codes, nil
+}
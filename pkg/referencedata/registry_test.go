@@ -0,0 +1,1139 @@
+package referencedata
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewRegistry(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(reg.Types()) == 0 || len(reg.Families()) == 0 || len(reg.Aliases()) == 0 {
+		t.Fatalf("expected all tables to be populated, got %d types, %d families, %d aliases", len(reg.Types()), len(reg.Families()), len(reg.Aliases()))
+		return
+	}
+}
+
+func TestNewRegistryParallelLoadingMatchesSequential(t *testing.T) {
+	parallel, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	sequential := newEmptyRegistry()
+	var readErr error
+	for _, at := range AircraftTypes(strings.NewReader(types), &readErr) {
+		sequential.addType(at)
+	}
+
+	for _, f := range AircraftFamilies(strings.NewReader(families), &readErr) {
+		sequential.addFamily(f)
+	}
+
+	for _, a := range AircraftAliases(strings.NewReader(aliases), &readErr) {
+		sequential.addAlias(a)
+	}
+
+	if readErr == nil {
+		readErr = sequential.loadEmbeddedSupplements()
+	}
+
+	if readErr != nil {
+		t.Fatal(readErr)
+		return
+	}
+
+	var parallelSnapshot, sequentialSnapshot bytes.Buffer
+	if err := parallel.WriteSnapshot(&parallelSnapshot); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err := sequential.WriteSnapshot(&sequentialSnapshot); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if !bytes.Equal(parallelSnapshot.Bytes(), sequentialSnapshot.Bytes()) {
+		t.Fatal("expected the registry loaded in parallel to match the one loaded sequentially")
+		return
+	}
+}
+
+func TestNewRegistryFromPathsOverridesSingleFile(t *testing.T) {
+	embedded, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	typesPath := filepath.Join(t.TempDir(), "aircraft_types.csv")
+	data := "id,family_id,iata,icao,wtc,engine_count,engine_type,name\nXXX,,XXX,XXXX,M,2,Jet,Test Aircraft\n"
+	if err := os.WriteFile(typesPath, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	reg, err := NewRegistryFromPaths(typesPath, "", "")
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(reg.Types()) != 1 {
+		t.Fatalf("expected 1 aircraft type, got %d", len(reg.Types()))
+		return
+	}
+
+	if at, ok := reg.Type("XXX"); !ok || at.Name != "Test Aircraft" || at.EngineCount != 2 {
+		t.Fatalf("unexpected aircraft type: %+v", at)
+		return
+	}
+
+	if len(reg.Families()) != len(embedded.Families()) {
+		t.Fatalf("expected %d embedded families, got %d", len(embedded.Families()), len(reg.Families()))
+		return
+	}
+
+	if len(reg.Aliases()) != len(embedded.Aliases()) {
+		t.Fatalf("expected %d embedded aliases, got %d", len(embedded.Aliases()), len(reg.Aliases()))
+		return
+	}
+}
+
+func TestNewRegistryFromPathsMissingFile(t *testing.T) {
+	if _, err := NewRegistryFromPaths(filepath.Join(t.TempDir(), "missing.csv"), "", ""); err == nil {
+		t.Fatal("expected error for missing file")
+		return
+	}
+}
+
+func TestNewRegistryInvalidCode(t *testing.T) {
+	_, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n320,,320,a320,Airbus A320\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err == nil {
+		t.Fatal("expected error for invalid icao code")
+		return
+	}
+}
+
+func TestLookupByICAO(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,wtc,engine_count,engine_type,name\n320,32S,320,A320,M,2,Jet,Airbus A320\n32A,32S,32A,A320,M,2,Jet,Airbus A320 (sharklets)\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,,family,Airbus A320\nDH8,DH8,DH8X,,family,Dash 8\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	matches, f, err := reg.LookupByICAO("A320")
+	if err != nil || f != nil || len(matches) != 2 {
+		t.Fatalf("expected 2 aircraft types for A320, got %v, %v, %v", matches, f, err)
+		return
+	}
+
+	matches, f, err = reg.LookupByICAO("DH8X")
+	if err != nil || len(matches) != 0 || f == nil || f.Id != "DH8" {
+		t.Fatalf("expected family fallback for DH8X, got %v, %v, %v", matches, f, err)
+		return
+	}
+
+	if _, _, err = reg.LookupByICAO("ZZZZ"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+		return
+	}
+}
+
+func TestLookupByIATA(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	res, err := reg.LookupByIATA("320")
+	if err != nil || res.Type == nil || res.Type.Id != "320" {
+		t.Fatalf("expected aircraft type 320, got %+v, %v", res, err)
+		return
+	}
+
+	res, err = reg.LookupByIATA("20N")
+	if err != nil || res.Type == nil || res.Type.Id != "32N" {
+		t.Fatalf("expected alias 20N to resolve to aircraft type 32N, got %+v, %v", res, err)
+		return
+	}
+
+	res, err = reg.LookupByIATA("737")
+	if err != nil || res.Family == nil || res.Family.Id != "737" {
+		t.Fatalf("expected family 737, got %+v, %v", res, err)
+		return
+	}
+
+	if _, err = reg.LookupByIATA("ZZZ"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+		return
+	}
+}
+
+func TestDeprecationLog(t *testing.T) {
+	reg := newEmptyRegistry()
+	if err := reg.loadDeprecationLog(strings.NewReader("old_iata,new_iata,aircraft_type_id,change_year,reason\n32X,320,320,2005,renamed\nZZ1,32X,external,2018,reused\nZZ2,ZZ3,external,2019,\n")); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	entries := reg.DeprecationLog("32X")
+	if len(entries) != 2 || entries[0].ChangeYear != 2005 || entries[1].AircraftTypeId != ExternalAircraftTypeId {
+		t.Fatalf("expected both reassignments of 32X in file order, got %v", entries)
+		return
+	}
+
+	if entries := reg.DeprecationLog("320"); len(entries) != 1 || entries[0].OldIATA != "32X" {
+		t.Fatalf("expected the reassignment to 320, got %v", entries)
+		return
+	}
+
+	if entries := reg.DeprecationLog("ZZZ"); len(entries) != 0 {
+		t.Fatalf("expected no entries for an unknown code, got %v", entries)
+		return
+	}
+}
+
+func TestRegulatoryCertificates(t *testing.T) {
+	reg := newEmptyRegistry()
+	if err := reg.loadRegulatory(strings.NewReader("aircraft_type_id,authority,certificate_number,issue_year\n320,EASA,A.064,1988\n320,FAA,A28NM,1988\n738,FAA,A16WE,1997\n")); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	certificates := reg.RegulatoryCertificates("320")
+	if len(certificates) != 2 || certificates[0].Authority != RegulatoryAuthorityEASA || certificates[1].CertificateNumber != "A28NM" {
+		t.Fatalf("expected both certificates of 320 in file order, got %v", certificates)
+		return
+	}
+
+	if certificates := reg.RegulatoryCertificates("738"); len(certificates) != 1 || certificates[0].IssueYear != 1997 {
+		t.Fatalf("expected the certificate of 738, got %v", certificates)
+		return
+	}
+
+	if certificates := reg.RegulatoryCertificates("XXX"); len(certificates) != 0 {
+		t.Fatalf("expected no certificates for an unknown type, got %v", certificates)
+		return
+	}
+}
+
+func TestPerformance(t *testing.T) {
+	reg := newEmptyRegistry()
+	if err := reg.loadPerformance(strings.NewReader("aircraft_type_id,cruise_speed_kmh,service_ceiling_ft,takeoff_distance_m,landing_distance_m\n320,830,39000,2100,1500\n")); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	p, err := reg.Performance("320")
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if expected := (AircraftPerformance{AircraftTypeId: "320", CruiseSpeedKmh: 830, ServiceCeilingFt: 39000, TakeoffDistanceM: 2100, LandingDistanceM: 1500}); *p != expected {
+		t.Fatalf("expected %+v, got %+v", expected, *p)
+		return
+	}
+
+	if _, err := reg.Performance("738"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a type without performance data, got %v", err)
+		return
+	}
+}
+
+func TestPrimaryPhoto(t *testing.T) {
+	reg := newEmptyRegistry()
+	if err := reg.loadPhotos(strings.NewReader("aircraft_type_id,sort_order,url,attribution,license\n320,2,https://example.com/b.jpg,B,CC0-1.0\n320,1,https://example.com/a.jpg,A,CC0-1.0\n320,1,https://example.com/c.jpg,C,CC0-1.0\n")); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	photo, err := reg.PrimaryPhoto("320")
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if photo.URL != "https://example.com/a.jpg" {
+		t.Fatalf("expected the first photo with the lowest sort order, got %+v", photo)
+		return
+	}
+
+	if _, err := reg.PrimaryPhoto("738"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a type without photos, got %v", err)
+		return
+	}
+}
+
+func TestConfigurationsFor(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,typical_seats,name\n320,,320,A320,180,Airbus A320\n738,,738,B738,,Boeing 737-800\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err := reg.loadSeatConfigurations(strings.NewReader("aircraft_type_id,config_name,first_seats,business_seats,premium_economy_seats,economy_seats\n320,1-class,,,,180\n320,2-class,0,12,0,150\n")); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	configs := reg.ConfigurationsFor("320")
+	if len(configs) != 2 || configs[0].ConfigName != "1-class" || configs[1].ConfigName != "2-class" {
+		t.Fatalf("expected the 1-class and 2-class configurations of 320, got %v", configs)
+		return
+	}
+
+	if total := configs[1].TotalSeats(); total != 162 {
+		t.Fatalf("expected 162 seats in the 2-class configuration, got %d", total)
+		return
+	}
+
+	if configs := reg.ConfigurationsFor("738"); len(configs) != 0 {
+		t.Fatalf("expected no configurations of 738, got %v", configs)
+		return
+	}
+
+	if err := reg.loadSeatConfigurations(strings.NewReader("aircraft_type_id,config_name,first_seats,business_seats,premium_economy_seats,economy_seats\n320,2-class,0,twelve,0,150\n")); err == nil {
+		t.Fatal("expected an error for a non-numeric seat count")
+		return
+	}
+}
+
+func TestBatchLookupByIATA(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	found, notFound := reg.BatchLookupByIATA([]string{"320", "20N", "ZZZ", "737", "320", "ZZZ"})
+	if len(found) != 2 || found["320"] == nil || found["320"].Id != "320" || found["20N"] == nil || found["20N"].Id != "32N" {
+		t.Fatalf("expected 320 and 20N to resolve to aircraft types, got %v", found)
+		return
+	}
+
+	if expected := []string{"ZZZ", "737"}; !slices.Equal(notFound, expected) {
+		t.Fatalf("expected unknown and family codes %v, got %v", expected, notFound)
+		return
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n320,32S,320,A320,Airbus A320\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,,family,Airbus A320 Family\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32A,320,\n32X,,32S\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, c := range []struct {
+		value   string
+		want    string
+		wantErr error
+	}{
+		{value: "320", want: "320"},
+		{value: "32A", want: "320"},
+		{value: "32X", wantErr: ErrFamilyAlias},
+		{value: "32S", wantErr: ErrFamilyAlias},
+		{value: "ZZZ", wantErr: ErrNotFound},
+	} {
+		got, err := reg.Canonicalize(c.value)
+		if !errors.Is(err, c.wantErr) {
+			t.Fatalf("Canonicalize(%q): expected error %v, got %v", c.value, c.wantErr, err)
+			return
+		}
+
+		if got != c.want {
+			t.Fatalf("Canonicalize(%q): expected %q, got %q", c.value, c.want, got)
+			return
+		}
+	}
+}
+
+func TestLookupByIATAHistorical(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n321,,321,A321,Airbus A321\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err := reg.loadHistoricalAliases(strings.NewReader("historical_iata,aircraft_type_id,valid_until_year\n32X,320,2010\n321,320,2000\n")); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	res, err := reg.LookupByIATA("32X")
+	if err != nil || res.Type == nil || res.Type.Id != "320" || !res.Historical {
+		t.Fatalf("expected historical code 32X to resolve to 320, got %+v, %v", res, err)
+		return
+	}
+
+	res, err = reg.LookupByIATA("321")
+	if err != nil || res.Type == nil || res.Type.Id != "321" || res.Historical {
+		t.Fatalf("expected current code 321 to take precedence over the historical code, got %+v, %v", res, err)
+		return
+	}
+}
+
+func TestLookupByIATAFollowSuperseded(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,superseded_by,name\n310,,310,A310,320,Airbus A310\n320,,320,A320,32N,Airbus A320\n32N,,32N,A20N,,Airbus A320neo\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	res, err := reg.LookupByIATA("310")
+	if err != nil || res.Type.Id != "310" || res.Deprecated {
+		t.Fatalf("expected 310 without following supersession, got %+v, %v", res, err)
+		return
+	}
+
+	res, err = reg.LookupByIATA("310", FollowSuperseded())
+	if err != nil || res.Type.Id != "32N" || !res.Deprecated {
+		t.Fatalf("expected 310 to resolve to 32N, got %+v, %v", res, err)
+		return
+	}
+
+	res, err = reg.LookupByIATA("32N", FollowSuperseded())
+	if err != nil || res.Type.Id != "32N" || res.Deprecated {
+		t.Fatalf("expected current type 32N not to be deprecated, got %+v, %v", res, err)
+		return
+	}
+}
+
+func TestAllAliasesFor(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,wtc,engine_count,engine_type,name\n320,,320,A320,M,2,Jet,Airbus A320\n321,,321,A321,M,2,Jet,Airbus A321\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,,family,Airbus A320\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32A,320,\n32B,320,\n32C,,32S\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, iata := range []IATA{"320", "32A", "32B"} {
+		codes, err := reg.AllAliasesFor(iata)
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		if expected := []IATA{"320", "32A", "32B"}; !slices.Equal(codes, expected) {
+			t.Fatalf("expected %v for %s, got %v", expected, iata, codes)
+			return
+		}
+	}
+
+	codes, err := reg.AllAliasesFor("321")
+	if err != nil || !slices.Equal(codes, []IATA{"321"}) {
+		t.Fatalf("expected only 321, got %v, %v", codes, err)
+		return
+	}
+
+	codes, err = reg.AllAliasesFor("32C")
+	if err != nil || !slices.Equal(codes, []IATA{"32C", "32S"}) {
+		t.Fatalf("expected family codes 32C and 32S, got %v, %v", codes, err)
+		return
+	}
+
+	if _, err = reg.AllAliasesFor("ZZZ"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+		return
+	}
+}
+
+func TestFamilyDescendants(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	descendants, aircraftTypes, err := reg.FamilyDescendants("BOEING")
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if !slices.ContainsFunc(descendants, func(f *AircraftFamily) bool { return f.Id == "737NG" }) {
+		t.Fatal("expected 737NG to be a descendant of BOEING")
+		return
+	}
+
+	if !slices.ContainsFunc(aircraftTypes, func(at *AircraftType) bool { return at.Id == "738" }) {
+		t.Fatal("expected 738 to be a descendant type of BOEING")
+		return
+	}
+
+	if _, _, err = reg.FamilyDescendants("does-not-exist"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+		return
+	}
+}
+
+func TestEnumerateRoots(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	roots := reg.EnumerateRoots()
+	if len(roots) == 0 {
+		t.Fatal("expected at least one root family")
+		return
+	}
+
+	for _, f := range roots {
+		if f.ParentFamilyId != "" {
+			t.Errorf("root family %q has parent family %q", f.Id, f.ParentFamilyId)
+		}
+	}
+
+	if !slices.IsSortedFunc(roots, func(a, b *AircraftFamily) int { return strings.Compare(a.Name, b.Name) }) {
+		t.Error("expected root families to be sorted by name")
+	}
+}
+
+func TestEnumerateLeafTypes(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	leafTypes := reg.EnumerateLeafTypes()
+	if len(leafTypes) == 0 {
+		t.Fatal("expected at least one leaf type")
+		return
+	}
+
+	for _, at := range leafTypes {
+		if at.FamilyId == "" {
+			continue
+		}
+
+		if subFamilies, _, err := reg.FamilyChildren(at.FamilyId); err != nil {
+			t.Errorf("aircraft type %q: %v", at.Id, err)
+		} else if len(subFamilies) > 0 {
+			t.Errorf("aircraft type %q belongs to family %q which has sub families", at.Id, at.FamilyId)
+		}
+	}
+
+	if !slices.ContainsFunc(leafTypes, func(at *AircraftType) bool { return at.Id == "738" }) {
+		t.Error("expected 738 to be a leaf type")
+	}
+}
+
+func TestCommercialName(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,commercial_name,name\n32S,32S,,,family,A320 Family,Airbus A320 Family\n737,737,,,family,,Boeing 737\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if f, _ := reg.Family("32S"); f.CommercialName() != "A320 Family" {
+		t.Fatalf("expected the commercial name, got %q", f.CommercialName())
+		return
+	}
+
+	if f, _ := reg.Family("737"); f.CommercialName() != "Boeing 737" {
+		t.Fatalf("expected the name as fallback, got %q", f.CommercialName())
+		return
+	}
+}
+
+func TestAircraftFamilyDepth(t *testing.T) {
+	csvReg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	snapshotReg, err := NewRegistryFromSnapshot()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, reg := range []*Registry{csvReg, snapshotReg} {
+		for id, expected := range map[string]int{"AIRBUS": 0, "32S": 1, "737NG": 2} {
+			f, ok := reg.Family(id)
+			if !ok {
+				t.Fatalf("expected family %s to exist", id)
+				return
+			}
+
+			if depth := f.Depth(); depth != expected {
+				t.Fatalf("expected depth %d for family %s, got %d", expected, id, depth)
+				return
+			}
+		}
+	}
+}
+
+func TestByEngineType(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	pistons := reg.ByEngineType(EngineTypePiston)
+	if len(pistons) == 0 {
+		t.Fatal("expected piston aircraft types")
+		return
+	}
+
+	for _, at := range pistons {
+		if at.EngineType != EngineTypePiston {
+			t.Fatalf("unexpected engine type %q for %s", at.EngineType, at.Id)
+			return
+		}
+	}
+}
+
+func TestIsSupersonic(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if at, ok := reg.Type("SSC"); !ok || !at.IsSupersonic() || at.MaxSpeedMach <= 1 {
+		t.Fatalf("expected the Concorde to be supersonic, got %+v", at)
+		return
+	}
+
+	if at, ok := reg.Type("320"); !ok || at.IsSupersonic() || at.MaxSpeedMach != 0 {
+		t.Fatalf("expected 320 not to be supersonic, got %+v", at)
+		return
+	}
+}
+
+func TestETOPS(t *testing.T) {
+	cases := []struct {
+		name         string
+		aircraftType *AircraftType
+		expected     int
+	}{
+		{name: "unknown range twin", aircraftType: &AircraftType{EngineCount: 2, EngineType: EngineTypeJet}, expected: 0},
+		{name: "short range twin", aircraftType: &AircraftType{EngineCount: 2, EngineType: EngineTypeJet, MaxRangeKm: 2500}, expected: 60},
+		{name: "medium range twin", aircraftType: &AircraftType{EngineCount: 2, EngineType: EngineTypeJet, MaxRangeKm: 6100}, expected: 180},
+		{name: "long range twin", aircraftType: &AircraftType{EngineCount: 2, EngineType: EngineTypeJet, MaxRangeKm: 11000}, expected: 240},
+		{name: "ultra long range twin", aircraftType: &AircraftType{EngineCount: 2, EngineType: EngineTypeJet, MaxRangeKm: 15600}, expected: 370},
+		{name: "quad", aircraftType: &AircraftType{EngineCount: 4, EngineType: EngineTypeJet, MaxRangeKm: 13800}, expected: 0},
+		{name: "turboprop", aircraftType: &AircraftType{EngineCount: 2, EngineType: EngineTypeTurboprop, MaxRangeKm: 1500}, expected: 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if etops := c.aircraftType.ETOPS(); etops != c.expected {
+				t.Fatalf("expected %d, got %d", c.expected, etops)
+				return
+			}
+		})
+	}
+
+	widebody := &AircraftType{EngineCount: 2, EngineType: EngineTypeJet, BodyType: BodyTypeWidebody, MaxRangeKm: 14000}
+	if etops := widebody.ETOPS(); etops < 180 {
+		t.Fatalf("expected a long range widebody twin to be rated at least 180, got %d", etops)
+		return
+	}
+}
+
+func TestETOPSEmbedded(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, id := range []string{"77W", "359"} {
+		t.Run(id, func(t *testing.T) {
+			at, ok := reg.Type(id)
+			if !ok {
+				t.Fatalf("expected aircraft type %s", id)
+				return
+			}
+
+			if at.MaxRangeKm == 0 {
+				t.Skipf("no max_range_km for aircraft type %s in the embedded data", id)
+				return
+			}
+
+			if etops := at.ETOPS(); etops < 180 {
+				t.Fatalf("expected the long range widebody twin %s to be rated at least 180, got %d", id, etops)
+				return
+			}
+		})
+	}
+
+	for _, id := range []string{"AT7", "DH4"} {
+		if at, ok := reg.Type(id); !ok || at.ETOPS() != 0 {
+			t.Fatalf("expected the turboprop %s not to be rated, got %+v", id, at)
+			return
+		}
+	}
+}
+
+func TestByWTC(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	superTypes := reg.ByWTC("J")
+	if len(superTypes) == 0 {
+		t.Fatal("expected aircraft types of wake turbulence category J")
+		return
+	}
+
+	for _, at := range superTypes {
+		if at.WTC != "J" {
+			t.Fatalf("unexpected wtc %q for %s", at.WTC, at.Id)
+			return
+		}
+	}
+
+	if res := reg.ByWTC("X"); len(res) != 0 {
+		t.Fatalf("expected no aircraft types for an unknown category, got %v", res)
+		return
+	}
+}
+
+func TestByCategory(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,cargo_variant,military,name\n320,,320,A320,false,false,Airbus A320\n32F,,32F,A320,true,false,Airbus A320 Freighter\nIL7,,IL7,IL76,false,true,Ilyushin Il-76\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, c := range []struct {
+		civil, cargo, military bool
+		expected               []string
+	}{
+		{civil: true, expected: []string{"320"}},
+		{cargo: true, expected: []string{"32F"}},
+		{military: true, expected: []string{"IL7"}},
+		{civil: true, cargo: true, expected: []string{"320", "32F"}},
+	} {
+		var ids []string
+		for _, at := range reg.ByCategory(c.civil, c.cargo, c.military) {
+			ids = append(ids, at.Id)
+		}
+
+		if !slices.Equal(ids, c.expected) {
+			t.Fatalf("expected %v for civil=%v cargo=%v military=%v, got %v", c.expected, c.civil, c.cargo, c.military, ids)
+			return
+		}
+	}
+}
+
+func TestByManufacturerRegion(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	families := reg.ByManufacturerRegion(ManufacturerRegionBrazil)
+	if len(families) == 0 {
+		t.Fatal("expected at least one family from Brazil")
+		return
+	}
+
+	for _, f := range families {
+		if f.ManufacturerRegion != ManufacturerRegionBrazil {
+			t.Fatalf("expected only families from Brazil, got %+v", f)
+			return
+		}
+	}
+
+	if families := reg.ByManufacturerRegion("Atlantis"); len(families) != 0 {
+		t.Fatalf("expected no families for an unknown region, got %v", families)
+		return
+	}
+}
+
+func TestCountryByCode(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	c, err := reg.CountryByCode("DE")
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if c.Name != "Germany" || c.Region != RegionEurope {
+		t.Fatalf("expected Germany in europe, got %+v", c)
+		return
+	}
+
+	if _, err := reg.CountryByCode("XX"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+		return
+	}
+}
+
+func TestSiblingTypes(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	siblings, err := reg.SiblingTypes("320")
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	ids := make(map[string]struct{}, len(siblings))
+	for _, sibling := range siblings {
+		ids[sibling.Id] = struct{}{}
+	}
+
+	for _, id := range []string{"318", "319", "321"} {
+		if _, ok := ids[id]; !ok {
+			t.Fatalf("expected %s to be a sibling of 320, got %v", id, siblings)
+			return
+		}
+	}
+
+	if _, ok := ids["320"]; ok {
+		t.Fatal("expected 320 not to be its own sibling")
+		return
+	}
+
+	if siblings, err := reg.SiblingTypes("143"); err != nil || siblings == nil || len(siblings) != 0 {
+		t.Fatalf("expected an empty slice for the only type of family 146, got %v, %v", siblings, err)
+		return
+	}
+
+	if _, err := reg.SiblingTypes("does-not-exist"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+		return
+	}
+}
+
+func TestFindLargerThan(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,typical_seats,name\n320,,320,A320,180,Airbus A320\n388,,388,A388,555,Airbus A380-800\nAT7,,AT7,AT72,,ATR 72\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if larger := reg.FindLargerThan(200); len(larger) != 1 || larger[0].Id != "388" {
+		t.Fatalf("expected only 388 to have more than 200 seats, got %v", larger)
+		return
+	}
+
+	if larger := reg.FindLargerThan(0); len(larger) != 2 {
+		t.Fatalf("expected aircraft types without seats to be excluded, got %v", larger)
+		return
+	}
+}
+
+func TestFlightRangeCategory(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,max_range_km,name\nAT7,,AT7,AT72,1528,ATR 72\n320,,320,A320,6100,Airbus A320\n77W,,77W,B77W,13650,Boeing 777-300ER\n31N,,31N,A19N,6000,Airbus A319neo\n738,,738,B738,,Boeing 737-800\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, c := range []struct {
+		typeId  string
+		want    RangeCategory
+		wantErr error
+	}{
+		{typeId: "AT7", want: RangeCategoryShortHaul},
+		{typeId: "320", want: RangeCategoryLongHaul},
+		{typeId: "77W", want: RangeCategoryUltraLongHaul},
+		{typeId: "31N", want: RangeCategoryLongHaul},
+		{typeId: "738", wantErr: ErrFieldMissing},
+		{typeId: "XXX", wantErr: ErrNotFound},
+	} {
+		got, err := reg.FlightRangeCategory(c.typeId)
+		if !errors.Is(err, c.wantErr) || got != c.want {
+			t.Fatalf("FlightRangeCategory(%q): expected %q, %v, got %q, %v", c.typeId, c.want, c.wantErr, got, err)
+			return
+		}
+	}
+
+	for _, c := range []struct {
+		km   int
+		want RangeCategory
+	}{
+		{km: 2999, want: RangeCategoryShortHaul},
+		{km: 3000, want: RangeCategoryMediumHaul},
+		{km: 5999, want: RangeCategoryMediumHaul},
+		{km: 6000, want: RangeCategoryLongHaul},
+		{km: 12000, want: RangeCategoryLongHaul},
+		{km: 12001, want: RangeCategoryUltraLongHaul},
+	} {
+		if got := rangeCategoryOf(c.km); got != c.want {
+			t.Fatalf("rangeCategoryOf(%d): expected %q, got %q", c.km, c.want, got)
+			return
+		}
+	}
+}
+
+func TestSearchByCharacteristics(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if all := reg.SearchByCharacteristics(AircraftFilter{}); len(all) != len(reg.Types()) {
+		t.Fatalf("expected an empty filter to match all %d aircraft types, got %d", len(reg.Types()), len(all))
+		return
+	}
+
+	widebody := BodyTypeWidebody
+	wide := reg.SearchByCharacteristics(AircraftFilter{BodyType: &widebody})
+	if len(wide) == 0 || len(wide) >= len(reg.Types()) {
+		t.Fatalf("expected a non-empty subset of widebody aircraft types, got %d of %d", len(wide), len(reg.Types()))
+		return
+	}
+
+	jet := EngineTypeJet
+	twin := 2
+	twinJets := reg.SearchByCharacteristics(AircraftFilter{BodyType: &widebody, EngineType: &jet, EngineCount: &twin})
+	if len(twinJets) == 0 || len(twinJets) >= len(wide) {
+		t.Fatalf("expected twin engine jets to be a non-empty subset of widebody aircraft types, got %d of %d", len(twinJets), len(wide))
+		return
+	}
+
+	for _, at := range twinJets {
+		if at.BodyType != widebody || at.EngineType != jet || at.EngineCount != twin {
+			t.Fatalf("unexpected aircraft type %+v", at)
+			return
+		}
+	}
+}
+
+func TestSearchByCharacteristicsSeats(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,typical_seats,deprecated,name\n319,,319,A319,140,false,Airbus A319\n320,,320,A320,180,false,Airbus A320\n321,,321,A321,220,true,Airbus A321\nAT7,,AT7,AT72,,false,ATR 72\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	minSeats, maxSeats := 150, 250
+	var ids []string
+	for _, at := range reg.SearchByCharacteristics(AircraftFilter{MinSeats: &minSeats, MaxSeats: &maxSeats}) {
+		ids = append(ids, at.Id)
+	}
+
+	if expected := []string{"320", "321"}; !slices.Equal(ids, expected) {
+		t.Fatalf("expected %v, got %v", expected, ids)
+		return
+	}
+
+	if active := reg.SearchByCharacteristics(AircraftFilter{MinSeats: &minSeats, ActiveOnly: true}); len(active) != 1 || active[0].Id != "320" {
+		t.Fatalf("expected only 320, got %v", active)
+		return
+	}
+}
+
+func TestLocalizedName(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n321,,321,A321,Airbus A321\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err := reg.loadNames(strings.NewReader("aircraft_type_id,locale,name\n320,de,Airbus A320 (de)\n320,zh-Hans,空客A320\n320,en,Airbus A320 (en)\n")); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, c := range []struct {
+		id       string
+		locale   string
+		expected string
+	}{
+		{id: "320", locale: "zh-Hans", expected: "空客A320"},
+		{id: "320", locale: "zh-Hans-CN", expected: "空客A320"},
+		{id: "320", locale: "de-AT", expected: "Airbus A320 (de)"},
+		{id: "320", locale: "fr", expected: "Airbus A320 (en)"},
+		{id: "321", locale: "de", expected: "Airbus A321"},
+		{id: "XXX", locale: "de", expected: ""},
+	} {
+		if name := reg.LocalizedName(c.id, c.locale, "en"); name != c.expected {
+			t.Fatalf("expected %q for %s in %s, got %q", c.expected, c.id, c.locale, name)
+			return
+		}
+	}
+}
+
+func TestManufacturer(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	at, _ := reg.Type("738")
+	if m, ok := reg.Manufacturer(at); !ok || m.Id != "BOEING" {
+		t.Fatalf("expected BOEING as manufacturer of 738, got %v", m)
+		return
+	}
+
+	at, _ = reg.Type("100")
+	if m, ok := reg.Manufacturer(at); ok {
+		t.Fatalf("expected no manufacturer for 100, got %v", m)
+		return
+	}
+}
+
+func BenchmarkLookupByIATA(b *testing.B) {
+	reg, err := NewRegistry()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	codes := []IATA{"320", "20N", "737", "ZZZ"}
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		reg.LookupByIATA(codes[i%len(codes)])
+	}
+}
+
+// benchmarkCodes returns n codes cycling through the codes of all aircraft types, aliases and an unknown code.
+func benchmarkCodes(b *testing.B, reg *Registry, n int) []string {
+	var all []string
+	for _, t := range reg.Types() {
+		all = append(all, string(t.IATA))
+	}
+
+	for _, a := range reg.Aliases() {
+		all = append(all, string(a.Alias))
+	}
+
+	all = append(all, "ZZZ")
+
+	codes := make([]string, n)
+	for i := range codes {
+		codes[i] = all[i%len(all)]
+	}
+
+	return codes
+}
+
+func BenchmarkLookupByIATA1000(b *testing.B) {
+	reg, err := NewRegistry()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// collect the same result as BatchLookupByIATA for a fair comparison
+	codes := benchmarkCodes(b, reg, 1000)
+	b.ReportAllocs()
+	for b.Loop() {
+		found := make(map[string]*AircraftType, len(codes))
+		var notFound []string
+		for _, code := range codes {
+			if res, err := reg.LookupByIATA(IATA(code)); err == nil && res.Type != nil {
+				found[code] = res.Type
+			} else if !slices.Contains(notFound, code) {
+				notFound = append(notFound, code)
+			}
+		}
+	}
+}
+
+func BenchmarkBatchLookupByIATA1000(b *testing.B) {
+	reg, err := NewRegistry()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	codes := benchmarkCodes(b, reg, 1000)
+	b.ReportAllocs()
+	for b.Loop() {
+		reg.BatchLookupByIATA(codes)
+	}
+}
+
+func BenchmarkFamilyDescendants(b *testing.B) {
+	reg, err := NewRegistry()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, _, err := reg.FamilyDescendants("BOEING"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
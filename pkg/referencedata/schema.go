@@ -0,0 +1,158 @@
+package referencedata
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	aircraftTypesSchema       = []string{"id", "family_id", "iata", "icao", "wtc", "engine_count", "engine_type", "body_type", "cargo_variant", "military", "typical_seats", "max_range_km", "supersonic", "max_speed_mach", "superseded_by", "deprecated", "retired_year", "notes", "name"}
+	aircraftFamiliesSchema    = []string{"id", "iata", "icao", "parent_family", "level", "manufacturer_region", "commercial_name", "name"}
+	aircraftAliasesSchema     = []string{"alias", "aircraft_type", "aircraft_family"}
+	aircraftVariantsSchema    = []string{"id", "aircraft_type_id", "name", "icao", "introduced_year"}
+	deprecationLogSchema      = []string{"old_iata", "new_iata", "aircraft_type_id", "change_year", "reason"}
+	regulatorySchema          = []string{"aircraft_type_id", "authority", "certificate_number", "issue_year"}
+	aircraftPerformanceSchema = []string{"aircraft_type_id", "cruise_speed_kmh", "service_ceiling_ft", "takeoff_distance_m", "landing_distance_m"}
+	aircraftPhotosSchema      = []string{"aircraft_type_id", "sort_order", "url", "attribution", "license"}
+	seatConfigurationsSchema  = []string{"aircraft_type_id", "config_name", "first_seats", "business_seats", "premium_economy_seats", "economy_seats"}
+	historicalAliasesSchema   = []string{"historical_iata", "aircraft_type_id", "valid_until_year"}
+	aircraftTypeNamesSchema   = []string{"aircraft_type_id", "locale", "name"}
+	countriesSchema           = []string{"code", "name", "region"}
+)
+
+// embeddedFiles lists the embedded csv files together with their expected columns.
+var embeddedFiles = []struct {
+	name    string
+	content string
+	schema  []string
+}{
+	{name: "aircraft_types.csv", content: types, schema: aircraftTypesSchema},
+	{name: "aircraft_type_names.csv", content: typeNames, schema: aircraftTypeNamesSchema},
+	{name: "aircraft_families.csv", content: families, schema: aircraftFamiliesSchema},
+	{name: "aircraft_aliases.csv", content: aliases, schema: aircraftAliasesSchema},
+	{name: "aircraft_variants.csv", content: variants, schema: aircraftVariantsSchema},
+	{name: "aircraft_types_deprecation_log.csv", content: deprecationLog, schema: deprecationLogSchema},
+	{name: "aircraft_regulatory.csv", content: regulatory, schema: regulatorySchema},
+	{name: "aircraft_type_performance.csv", content: performance, schema: aircraftPerformanceSchema},
+	{name: "aircraft_type_photos.csv", content: photos, schema: aircraftPhotosSchema},
+	{name: "aircraft_seat_configurations.csv", content: seatConfigurations, schema: seatConfigurationsSchema},
+	{name: "historical_aliases.csv", content: historicalAliases, schema: historicalAliasesSchema},
+	{name: "countries.csv", content: countries, schema: countriesSchema},
+}
+
+// aircraftTypeFields returns the values of t in the order of aircraftTypesSchema.
+func aircraftTypeFields(t *AircraftType) []string {
+	return []string{t.Id, t.FamilyId, string(t.IATA), string(t.ICAO), t.WTC, optionalIntString(t.EngineCount), string(t.EngineType), string(t.BodyType), strconv.FormatBool(t.CargoVariant), strconv.FormatBool(t.Military), optionalIntString(t.TypicalSeats), optionalIntString(t.MaxRangeKm), strconv.FormatBool(t.Supersonic), optionalFloatString(t.MaxSpeedMach), t.SupersededBy, strconv.FormatBool(t.Deprecated), optionalIntString(t.RetiredYear), t.Notes, t.Name}
+}
+
+// aircraftFamilyFields returns the values of f in the order of aircraftFamiliesSchema.
+func aircraftFamilyFields(f *AircraftFamily) []string {
+	return []string{f.Id, string(f.IATA), string(f.ICAO), f.ParentFamilyId, f.Level, string(f.ManufacturerRegion), f.MarketingName, f.Name}
+}
+
+// aircraftAliasFields returns the values of a in the order of aircraftAliasesSchema.
+func aircraftAliasFields(a *AircraftAlias) []string {
+	return []string{string(a.Alias), a.AircraftTypeId, a.AircraftFamilyId}
+}
+
+// aircraftVariantFields returns the values of v in the order of aircraftVariantsSchema.
+func aircraftVariantFields(v *AircraftVariant) []string {
+	return []string{v.Id, v.AircraftTypeId, v.Name, string(v.ICAO), optionalIntString(v.IntroducedYear)}
+}
+
+// seatConfigurationFields returns the values of c in the order of seatConfigurationsSchema.
+func seatConfigurationFields(c *SeatConfiguration) []string {
+	return []string{c.AircraftTypeId, c.ConfigName, strconv.Itoa(c.FirstSeats), strconv.Itoa(c.BusinessSeats), strconv.Itoa(c.PremiumEconomySeats), strconv.Itoa(c.EconomySeats)}
+}
+
+// aircraftPhotoFields returns the values of p in the order of aircraftPhotosSchema.
+func aircraftPhotoFields(p *AircraftPhoto) []string {
+	return []string{p.AircraftTypeId, strconv.Itoa(p.SortOrder), p.URL, p.Attribution, p.License}
+}
+
+// aircraftPerformanceFields returns the values of p in the order of aircraftPerformanceSchema.
+func aircraftPerformanceFields(p *AircraftPerformance) []string {
+	return []string{p.AircraftTypeId, optionalIntString(p.CruiseSpeedKmh), optionalIntString(p.ServiceCeilingFt), optionalIntString(p.TakeoffDistanceM), optionalIntString(p.LandingDistanceM)}
+}
+
+// deprecationEntryFields returns the values of e in the order of deprecationLogSchema.
+func deprecationEntryFields(e *DeprecationEntry) []string {
+	return []string{string(e.OldIATA), string(e.NewIATA), e.AircraftTypeId, strconv.Itoa(e.ChangeYear), e.Reason}
+}
+
+// regulatoryEntryFields returns the values of e in the order of regulatorySchema.
+func regulatoryEntryFields(e *RegulatoryEntry) []string {
+	return []string{e.AircraftTypeId, string(e.Authority), e.CertificateNumber, strconv.Itoa(e.IssueYear)}
+}
+
+// historicalAliasFields returns the values of h in the order of historicalAliasesSchema.
+func historicalAliasFields(h *HistoricalAlias) []string {
+	return []string{string(h.HistoricalIATA), h.AircraftTypeId, optionalIntString(h.ValidUntilYear)}
+}
+
+// aircraftTypeNameFields returns the values of n in the order of aircraftTypeNamesSchema.
+func aircraftTypeNameFields(n *AircraftTypeName) []string {
+	return []string{n.AircraftTypeId, n.Locale, n.Name}
+}
+
+// countryFields returns the values of c in the order of countriesSchema.
+func countryFields(c *Country) []string {
+	return []string{c.Code, c.Name, string(c.Region)}
+}
+
+// optionalIntString formats n, leaving zero empty like the optional integer columns of the csv files.
+func optionalIntString(n int) string {
+	if n == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(n)
+}
+
+// optionalFloatString formats f in its shortest representation, returning an empty string for zero.
+func optionalFloatString(f float64) string {
+	if f == 0 {
+		return ""
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// ValidateSchema reads the header row from r and checks that it consists of exactly the expected columns.
+// The order of the columns is not checked.
+func ValidateSchema(r io.Reader, expected []string) error {
+	headers, err := csv.NewReader(r).Read()
+	if err != nil {
+		return fmt.Errorf("failed to read header: %w", err)
+	}
+
+	actual := make(map[string]struct{}, len(headers))
+	for _, h := range headers {
+		actual[h] = struct{}{}
+	}
+
+	expectedSet := make(map[string]struct{}, len(expected))
+	var missing []string
+	for _, column := range expected {
+		expectedSet[column] = struct{}{}
+		if _, ok := actual[column]; !ok {
+			missing = append(missing, column)
+		}
+	}
+
+	var extra []string
+	for _, h := range headers {
+		if _, ok := expectedSet[h]; !ok {
+			extra = append(extra, h)
+		}
+	}
+
+	if len(missing) > 0 || len(extra) > 0 {
+		return fmt.Errorf("header mismatch: missing columns [%s], extra columns [%s]", strings.Join(missing, ", "), strings.Join(extra, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package referencedata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSchema(t *testing.T) {
+	if err := ValidateSchema(strings.NewReader("b,a\n1,2\n"), []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	err := ValidateSchema(strings.NewReader("a,c\n"), []string{"a", "b"})
+	if err == nil {
+		t.Fatal("expected header mismatch")
+		return
+	}
+
+	if msg := err.Error(); !strings.Contains(msg, "missing columns [b]") || !strings.Contains(msg, "extra columns [c]") {
+		t.Fatalf("expected missing and extra columns in error, got %q", msg)
+		return
+	}
+}
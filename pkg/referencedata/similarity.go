@@ -0,0 +1,94 @@
+package referencedata
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// SimilarityWeights are the weights of the distance function used by ClosestAlternatives.
+// A lower distance means a more similar aircraft type.
+type SimilarityWeights struct {
+	// Seats is added per 100 seats of difference in typical seats.
+	// If the seat count of either aircraft type is unknown it is added once.
+	Seats float64
+	// EngineType is added if the engine types differ.
+	EngineType float64
+	// BodyType is added if the body types differ.
+	BodyType float64
+	// SameFamily is subtracted if both aircraft types belong to the same family.
+	SameFamily float64
+}
+
+// DefaultSimilarityWeights are used by ClosestAlternatives unless WithSimilarityWeights is given.
+var DefaultSimilarityWeights = SimilarityWeights{
+	Seats:      1,
+	EngineType: 1,
+	BodyType:   2,
+	SameFamily: 1.5,
+}
+
+// WithSimilarityWeights makes ClosestAlternatives use the given weights.
+func WithSimilarityWeights(w SimilarityWeights) RegistryOption {
+	return func(r *Registry) {
+		r.similarityWeights = w
+	}
+}
+
+// ClosestAlternatives returns the n aircraft types most similar to the aircraft type the IATA code resolves to,
+// most similar first. Ties are in file order. It is an error if the code resolves to a family.
+func (r *Registry) ClosestAlternatives(iata IATA, n int) ([]*AircraftType, error) {
+	res, err := r.LookupByIATA(iata)
+	if err != nil {
+		return nil, err
+	} else if res.Type == nil {
+		return nil, fmt.Errorf("iata %q resolves to family %q, not an aircraft type", iata, res.Family.Id)
+	}
+
+	type candidate struct {
+		t        *AircraftType
+		distance float64
+	}
+
+	candidates := make([]candidate, 0, len(r.types))
+	for _, t := range r.types {
+		if t.Id != res.Type.Id {
+			candidates = append(candidates, candidate{t, r.similarityWeights.distance(res.Type, t)})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(a.distance, b.distance)
+	})
+
+	alternatives := make([]*AircraftType, 0, min(max(n, 0), len(candidates)))
+	for _, c := range candidates[:cap(alternatives)] {
+		alternatives = append(alternatives, c.t)
+	}
+
+	return alternatives, nil
+}
+
+func (w SimilarityWeights) distance(a, b *AircraftType) float64 {
+	var d float64
+	if a.TypicalSeats == 0 || b.TypicalSeats == 0 {
+		d += w.Seats
+	} else {
+		d += w.Seats * math.Abs(float64(a.TypicalSeats-b.TypicalSeats)) / 100
+	}
+
+	if a.EngineType != b.EngineType {
+		d += w.EngineType
+	}
+
+	if a.BodyType != b.BodyType {
+		d += w.BodyType
+	}
+
+	if a.FamilyId != "" && a.FamilyId == b.FamilyId {
+		d -= w.SameFamily
+	}
+
+	return d
+}
@@ -0,0 +1,72 @@
+package referencedata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClosestAlternatives(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	alternatives, err := reg.ClosestAlternatives("32N", 5)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(alternatives) != 5 {
+		t.Fatalf("expected 5 alternatives, got %d", len(alternatives))
+		return
+	}
+
+	if alternatives[0].BodyType != BodyTypeNarrowbody || alternatives[0].Id == "32N" {
+		t.Fatalf("expected another narrowbody as closest alternative to the A320neo, got %+v", alternatives[0])
+		return
+	}
+
+	if _, err := reg.ClosestAlternatives("737", 5); err == nil {
+		t.Fatal("expected an error for a family code")
+		return
+	}
+}
+
+func TestClosestAlternativesWeights(t *testing.T) {
+	aircraftTypes := "id,family_id,iata,icao,engine_type,body_type,typical_seats,name\n" +
+		"320,32S,320,A320,Jet,narrowbody,180,Airbus A320\n" +
+		"321,32S,321,A321,Jet,narrowbody,220,Airbus A321\n" +
+		"738,737,738,B738,Jet,narrowbody,180,Boeing 737-800\n"
+
+	for _, tc := range []struct {
+		weights  SimilarityWeights
+		expected string
+	}{
+		{DefaultSimilarityWeights, "321"},
+		{SimilarityWeights{Seats: 10}, "738"},
+	} {
+		reg, err := newRegistry(
+			strings.NewReader(aircraftTypes),
+			strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,,family,Airbus A320\n737,737,,,family,Boeing 737\n"),
+			strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+		)
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		reg.apply([]RegistryOption{WithSimilarityWeights(tc.weights)})
+		alternatives, err := reg.ClosestAlternatives("320", 1)
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		if len(alternatives) != 1 || alternatives[0].Id != tc.expected {
+			t.Fatalf("expected %s as closest alternative with weights %+v, got %v", tc.expected, tc.weights, alternatives)
+			return
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package referencedata
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+//go:generate go run ../../cmd/reference-data snapshot -o registry.gob
+
+//go:embed registry.gob
+var snapshot []byte
+
+// registrySnapshot is the gob encoded form of a Registry. Indices are rebuilt when reading a snapshot.
+type registrySnapshot struct {
+	Types             []*AircraftType
+	Families          []*AircraftFamily
+	Aliases           []*AircraftAlias
+	Variants          []*AircraftVariant
+	SeatConfigs       []*SeatConfiguration
+	Photos            []*AircraftPhoto
+	Performance       []*AircraftPerformance
+	DeprecationLog    []*DeprecationEntry
+	Regulatory        []*RegulatoryEntry
+	HistoricalAliases []*HistoricalAlias
+	TypeNames         []*AircraftTypeName
+	Countries         []*Country
+}
+
+// NewRegistryFromSnapshot builds a Registry from the embedded snapshot, which avoids parsing the CSV files.
+// The snapshot is generated from the embedded CSV files by go generate.
+func NewRegistryFromSnapshot(opts ...RegistryOption) (*Registry, error) {
+	r, err := readSnapshot(bytes.NewReader(snapshot))
+	if err != nil {
+		return nil, err
+	}
+
+	r.apply(opts)
+	return r, nil
+}
+
+// WriteSnapshot writes a snapshot of r to w which can be read by NewRegistryFromSnapshot.
+func (r *Registry) WriteSnapshot(w io.Writer) error {
+	return gob.NewEncoder(w).Encode(registrySnapshot{
+		Types:             r.types,
+		Families:          r.families,
+		Aliases:           r.aliases,
+		Variants:          r.variants,
+		SeatConfigs:       r.seatConfigurations,
+		Photos:            r.photos,
+		Performance:       r.performance,
+		DeprecationLog:    r.deprecationLog,
+		Regulatory:        r.regulatory,
+		HistoricalAliases: r.historicalAliases,
+		TypeNames:         r.typeNames,
+		Countries:         r.countries,
+	})
+}
+
+func readSnapshot(reader io.Reader) (*Registry, error) {
+	var s registrySnapshot
+	if err := gob.NewDecoder(reader).Decode(&s); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	r := newEmptyRegistry()
+	for _, t := range s.Types {
+		r.addType(t)
+	}
+
+	for _, f := range s.Families {
+		r.addFamily(f)
+	}
+
+	r.computeFamilyDepths()
+
+	for _, a := range s.Aliases {
+		r.addAlias(a)
+	}
+
+	for _, v := range s.Variants {
+		r.addVariant(v)
+	}
+
+	for _, e := range s.DeprecationLog {
+		r.addDeprecationEntry(e)
+	}
+
+	for _, e := range s.Regulatory {
+		r.addRegulatoryEntry(e)
+	}
+
+	for _, p := range s.Performance {
+		r.addPerformance(p)
+	}
+
+	for _, p := range s.Photos {
+		r.addPhoto(p)
+	}
+
+	for _, c := range s.SeatConfigs {
+		r.addSeatConfiguration(c)
+	}
+
+	for _, h := range s.HistoricalAliases {
+		r.addHistoricalAlias(h)
+	}
+
+	for _, n := range s.TypeNames {
+		r.addTypeName(n)
+	}
+
+	for _, c := range s.Countries {
+		r.addCountry(c)
+	}
+
+	return r, nil
+}
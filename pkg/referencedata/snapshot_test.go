@@ -0,0 +1,68 @@
+package referencedata
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotIsCurrent(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := reg.WriteSnapshot(&buf); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if !bytes.Equal(buf.Bytes(), snapshot) {
+		t.Fatal("registry.gob is outdated, run go generate")
+		return
+	}
+}
+
+func TestNewRegistryFromSnapshot(t *testing.T) {
+	expected, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	reg, err := NewRegistryFromSnapshot()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if !reflect.DeepEqual(reg.Types(), expected.Types()) || !reflect.DeepEqual(reg.Families(), expected.Families()) || !reflect.DeepEqual(reg.Aliases(), expected.Aliases()) || !reflect.DeepEqual(reg.Countries(), expected.Countries()) {
+		t.Fatal("snapshot does not match the registry built from the csv files")
+		return
+	}
+
+	if res, err := reg.LookupByIATA("20N"); err != nil || res.Type == nil || res.Type.Id != "32N" {
+		t.Fatalf("expected alias 20N to resolve to 32N, got %+v, %v", res, err)
+		return
+	}
+}
+
+func BenchmarkNewRegistry(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := NewRegistry(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewRegistryFromSnapshot(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := NewRegistryFromSnapshot(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
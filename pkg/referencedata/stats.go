@@ -0,0 +1,62 @@
+package referencedata
+
+// RegistryStats are metrics describing the size and completeness of a Registry.
+type RegistryStats struct {
+	TotalTypes    int `json:"totalTypes"`
+	TotalFamilies int `json:"totalFamilies"`
+	TotalAliases  int `json:"totalAliases"`
+	// MaxFamilyDepth is the number of levels of the deepest family hierarchy, root families are at level 1.
+	MaxFamilyDepth int `json:"maxFamilyDepth"`
+	// AvgAliasesPerType is the average number of aliases of an aircraft type, not counting its own code.
+	AvgAliasesPerType float64 `json:"avgAliasesPerType"`
+	TypesWithNoAlias  int     `json:"typesWithNoAlias"`
+	TypesWithNoFamily int     `json:"typesWithNoFamily"`
+	ActiveTypes       int     `json:"activeTypes"`
+	DeprecatedTypes   int     `json:"deprecatedTypes"`
+}
+
+// ComputeStatistics returns the statistics of r. They are computed on the first call and cached afterwards.
+func (r *Registry) ComputeStatistics() *RegistryStats {
+	r.statsOnce.Do(func() {
+		r.stats = r.computeStatistics()
+	})
+
+	return r.stats
+}
+
+func (r *Registry) computeStatistics() *RegistryStats {
+	stats := &RegistryStats{
+		TotalTypes:    len(r.types),
+		TotalFamilies: len(r.families),
+		TotalAliases:  len(r.aliases),
+	}
+
+	var typeAliases int
+	for _, t := range r.types {
+		aliases := len(r.aliasesByTypeId[t.Id])
+		typeAliases += aliases
+		if aliases == 0 {
+			stats.TypesWithNoAlias++
+		}
+
+		if _, ok := r.familyById[t.FamilyId]; !ok {
+			stats.TypesWithNoFamily++
+		}
+
+		if t.Deprecated {
+			stats.DeprecatedTypes++
+		} else {
+			stats.ActiveTypes++
+		}
+	}
+
+	if len(r.types) > 0 {
+		stats.AvgAliasesPerType = float64(typeAliases) / float64(len(r.types))
+	}
+
+	for _, f := range r.families {
+		stats.MaxFamilyDepth = max(stats.MaxFamilyDepth, f.Depth()+1)
+	}
+
+	return stats
+}
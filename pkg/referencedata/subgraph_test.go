@@ -0,0 +1,84 @@
+package referencedata
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuildSubgraph(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	sub, err := reg.BuildSubgraph("737")
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(sub.Types()) == 0 || len(sub.Types()) >= len(reg.Types()) {
+		t.Fatalf("expected the subgraph to contain fewer aircraft types than the registry, got %d of %d", len(sub.Types()), len(reg.Types()))
+		return
+	}
+
+	if _, ok := sub.Type("738"); !ok {
+		t.Fatal("expected 738 to be part of the subgraph")
+		return
+	}
+
+	if _, ok := sub.Type("320"); ok {
+		t.Fatal("expected 320 not to be part of the subgraph")
+		return
+	}
+
+	if _, ok := sub.Family("BOEING"); ok {
+		t.Fatal("expected the parent of the root family not to be part of the subgraph")
+		return
+	}
+
+	if root, ok := sub.Family("737"); !ok || root.ParentFamilyId != "" || root.Depth() != 0 {
+		t.Fatalf("expected 737 to be a root family of the subgraph, got %+v", root)
+		return
+	}
+
+	if original, _ := reg.Family("737"); original.ParentFamilyId != "BOEING" {
+		t.Fatalf("expected the family of the registry to be unchanged, got %+v", original)
+		return
+	}
+
+	for _, at := range sub.Types() {
+		if _, ok := sub.Family(at.FamilyId); !ok {
+			t.Errorf("family %q of aircraft type %q is not part of the subgraph", at.FamilyId, at.Id)
+		}
+
+		if _, ok := sub.Type(at.SupersededBy); at.SupersededBy != "" && !ok {
+			t.Errorf("aircraft type %q is superseded by %q which is not part of the subgraph", at.Id, at.SupersededBy)
+		}
+	}
+
+	for _, f := range sub.Families() {
+		if _, ok := sub.Family(f.ParentFamilyId); f.ParentFamilyId != "" && !ok {
+			t.Errorf("parent family %q of family %q is not part of the subgraph", f.ParentFamilyId, f.Id)
+		}
+	}
+
+	for _, a := range sub.Aliases() {
+		_, typeOk := sub.Type(a.AircraftTypeId)
+		_, familyOk := sub.Family(a.AircraftFamilyId)
+		if !typeOk && !familyOk {
+			t.Errorf("alias %q points outside of the subgraph", a.Alias)
+		}
+	}
+
+	if err = validateRegistry(sub); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err = reg.BuildSubgraph("does-not-exist"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+		return
+	}
+}
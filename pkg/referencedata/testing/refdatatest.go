@@ -0,0 +1,108 @@
+// Package refdatatest provides fixtures for tests of code using the referencedata package.
+package refdatatest
+
+import (
+	"fmt"
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"strings"
+)
+
+// AircraftType returns an Airbus A320 with opts applied in order.
+func AircraftType(opts ...func(*referencedata.AircraftType)) *referencedata.AircraftType {
+	t := &referencedata.AircraftType{
+		Id:           "320",
+		FamilyId:     "32S",
+		IATA:         "320",
+		ICAO:         "A320",
+		WTC:          "M",
+		EngineCount:  2,
+		EngineType:   referencedata.EngineTypeJet,
+		BodyType:     referencedata.BodyTypeNarrowbody,
+		TypicalSeats: 180,
+		MaxRangeKm:   6100,
+		Name:         "Airbus A320",
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// AircraftFamily returns the Airbus A320 family with opts applied in order.
+func AircraftFamily(opts ...func(*referencedata.AircraftFamily)) *referencedata.AircraftFamily {
+	f := &referencedata.AircraftFamily{
+		Id:                 "32S",
+		IATA:               "32S",
+		Level:              "family",
+		ManufacturerRegion: referencedata.ManufacturerRegionEurope,
+		Name:               "Airbus A320 Family",
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// AircraftAlias returns an alias of the Airbus A320 with opts applied in order.
+func AircraftAlias(opts ...func(*referencedata.AircraftAlias)) *referencedata.AircraftAlias {
+	a := &referencedata.AircraftAlias{
+		Alias:          "32A",
+		AircraftTypeId: "320",
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+const (
+	miniTypes = `id,family_id,iata,icao,wtc,engine_count,engine_type,body_type,typical_seats,name
+319,32S,319,A319,M,2,Jet,narrowbody,140,Airbus A319
+320,32S,320,A320,M,2,Jet,narrowbody,180,Airbus A320
+321,32S,321,A321,M,2,Jet,narrowbody,220,Airbus A321
+738,737NG,738,B738,M,2,Jet,narrowbody,189,Boeing 737-800
+739,737NG,739,B739,M,2,Jet,narrowbody,215,Boeing 737-900
+`
+	miniFamilies = `id,iata,icao,parent_family,level,name
+32S,32S,,,family,Airbus A320 Family
+737,737,,,family,Boeing 737
+737NG,73N,,737,sub_family,Boeing 737 NG
+`
+	miniAliases = `alias,aircraft_type,aircraft_family
+31A,319,
+31B,319,
+32A,320,
+32B,320,
+32C,321,
+73A,738,
+73B,738,
+73C,739,
+3SA,,32S
+7NA,,737NG
+`
+)
+
+// MiniRegistry returns a consistent registry of five aircraft types, three families and ten aliases:
+// the Airbus A319, A320 and A321 in the family 32S, and the Boeing 737-800 and 737-900
+// in the sub family 737NG of the family 737.
+// Unlike referencedata.NewRegistry it contains no supplementary data such as variants.
+func MiniRegistry() *referencedata.Registry {
+	reg, validationErrors, err := referencedata.LoadAndValidate(
+		strings.NewReader(miniTypes),
+		strings.NewReader(miniFamilies),
+		strings.NewReader(miniAliases),
+	)
+	if err != nil {
+		panic(err)
+	} else if len(validationErrors) > 0 {
+		panic(fmt.Sprintf("invalid mini registry: %v", validationErrors))
+	}
+
+	return reg
+}
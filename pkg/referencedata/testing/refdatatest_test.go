@@ -0,0 +1,31 @@
+package refdatatest
+
+import (
+	"github.com/explore-flights/reference-data/pkg/referencedata"
+	"testing"
+)
+
+func TestMiniRegistry(t *testing.T) {
+	reg := MiniRegistry()
+	if len(reg.Types()) != 5 || len(reg.Families()) != 3 || len(reg.Aliases()) != 10 {
+		t.Fatalf("expected 5 types, 3 families and 10 aliases, got %d, %d, %d", len(reg.Types()), len(reg.Families()), len(reg.Aliases()))
+		return
+	}
+
+	if res, err := reg.LookupByIATA("7NA"); err != nil || res.Family == nil || res.Family.Id != "737NG" {
+		t.Fatalf("expected alias 7NA to resolve to family 737NG, got %+v, %v", res, err)
+		return
+	}
+}
+
+func TestAircraftType(t *testing.T) {
+	at := AircraftType(func(at *referencedata.AircraftType) {
+		at.Id = "321"
+		at.Name = "Airbus A321"
+	})
+
+	if at.Id != "321" || at.Name != "Airbus A321" || at.IATA != "320" || at.EngineType != referencedata.EngineTypeJet {
+		t.Fatalf("expected the overridden fields and defaults otherwise, got %+v", at)
+		return
+	}
+}
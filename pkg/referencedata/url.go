@@ -0,0 +1,158 @@
+package referencedata
+
+import (
+	"context"
+	"fmt"
+	"github.com/explore-flights/reference-data/internal/atomicfile"
+	"golang.org/x/sync/errgroup"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// remoteFiles are the names of the csv files fetched by LoadFromURL and CacheToDir.
+var remoteFiles = []string{"aircraft_types.csv", "aircraft_families.csv", "aircraft_aliases.csv"}
+
+// LoadFromURL builds a Registry from aircraft_types.csv, aircraft_families.csv and aircraft_aliases.csv below baseURL.
+// The files are fetched concurrently and parsed while they are downloaded, ctx applies to the whole download.
+// Like NewRegistryFromDir it loads the supplementary embedded files.
+func LoadFromURL(ctx context.Context, baseURL string, opts ...RegistryOption) (*Registry, error) {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	bodies := make([]io.ReadCloser, len(remoteFiles))
+	defer func() {
+		for _, body := range bodies {
+			if body != nil {
+				body.Close()
+			}
+		}
+	}()
+
+	// the context of errgroup.WithContext is canceled once Wait returns, which would abort reading the bodies
+	var g errgroup.Group
+	for i, name := range remoteFiles {
+		g.Go(func() error {
+			body, err := fetch(ctx, baseURL+"/"+name)
+			if err != nil {
+				return fmt.Errorf("failed to fetch %s: %w", name, err)
+			}
+
+			bodies[i] = body
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	r, err := newRegistry(bodies[0], bodies[1], bodies[2])
+	if err != nil {
+		return nil, err
+	}
+
+	r.apply(opts)
+	return r, r.loadEmbeddedSupplements()
+}
+
+// CacheToDir builds a Registry like LoadFromURL but keeps the fetched files in dir.
+// If all files in dir have been modified within ttl they are used without contacting baseURL,
+// otherwise they are fetched again and replaced. The cached files are only replaced once all of them have been fetched
+// and form a valid registry, a failed download or invalid data leaves the previous cache untouched.
+func CacheToDir(ctx context.Context, baseURL, dir string, ttl time.Duration, opts ...RegistryOption) (*Registry, error) {
+	if cacheIsFresh(dir, ttl) {
+		return NewRegistryFromDir(dir, opts...)
+	}
+
+	return refreshCache(ctx, strings.TrimSuffix(baseURL, "/"), dir, opts)
+}
+
+// refreshCache downloads all remote files into a temporary directory below dir, builds the registry from them and
+// moves them into dir once every download succeeded and the registry is valid.
+func refreshCache(ctx context.Context, baseURL, dir string, opts []RegistryOption) (*Registry, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp(dir, ".download-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	g, gCtx := errgroup.WithContext(ctx)
+	for _, name := range remoteFiles {
+		g.Go(func() error {
+			if err := download(gCtx, baseURL+"/"+name, filepath.Join(tmpDir, name)); err != nil {
+				return fmt.Errorf("failed to fetch %s: %w", name, err)
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	r, err := NewRegistryFromDir(tmpDir, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range remoteFiles {
+		if err := os.Rename(filepath.Join(tmpDir, name), filepath.Join(dir, name)); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// cacheIsFresh reports whether all remote files exist in dir and have been modified within ttl.
+func cacheIsFresh(dir string, ttl time.Duration) bool {
+	for _, name := range remoteFiles {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil || time.Since(info.ModTime()) >= ttl {
+			return false
+		}
+	}
+
+	return true
+}
+
+// download replaces the file at path with the body of a successful GET request to url.
+func download(ctx context.Context, url, path string) error {
+	body, err := fetch(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	return atomicfile.Write(path, func(w io.Writer) error {
+		_, err := io.Copy(w, body)
+		return err
+	})
+}
+
+// fetch returns the body of a successful GET request to url which must be closed by the caller.
+func fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,230 @@
+package referencedata
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestLoadFromURL(t *testing.T) {
+	srv := httptest.NewServer(http.FileServerFS(fstest.MapFS{
+		"aircraft_types.csv":    {Data: []byte("id,family_id,iata,icao,name\n320,32S,320,A320,Airbus A320\n")},
+		"aircraft_families.csv": {Data: []byte("id,iata,icao,parent_family,level,name\n32S,32S,,,family,Airbus A320 Family\n")},
+		"aircraft_aliases.csv":  {Data: []byte("alias,aircraft_type,aircraft_family\n32A,320,\n")},
+	}))
+	defer srv.Close()
+
+	reg, err := LoadFromURL(context.Background(), srv.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(reg.Types()) != 1 || len(reg.Families()) != 1 || len(reg.Aliases()) != 1 {
+		t.Fatalf("expected 1 type, 1 family and 1 alias, got %d, %d, %d", len(reg.Types()), len(reg.Families()), len(reg.Aliases()))
+		return
+	}
+
+	if res, err := reg.LookupByIATA("32A"); err != nil || res.Type == nil || res.Type.Id != "320" {
+		t.Fatalf("expected alias 32A to resolve to 320, got %+v, %v", res, err)
+		return
+	}
+}
+
+func TestLoadFromURLMissingFile(t *testing.T) {
+	srv := httptest.NewServer(http.FileServerFS(fstest.MapFS{
+		"aircraft_types.csv":    {Data: []byte("id,family_id,iata,icao,name\n")},
+		"aircraft_families.csv": {Data: []byte("id,iata,icao,parent_family,level,name\n")},
+	}))
+	defer srv.Close()
+
+	if _, err := LoadFromURL(context.Background(), srv.URL); err == nil {
+		t.Fatal("expected error for missing aircraft_aliases.csv")
+		return
+	}
+}
+
+func TestLoadFromURLCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the server sends the header and cancels the download before the rest of the file
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "id,family_id,iata,icao,name\n")
+		w.(http.Flusher).Flush()
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	if _, err := LoadFromURL(ctx, srv.URL); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+		return
+	}
+}
+
+func TestCacheToDir(t *testing.T) {
+	var requests atomic.Int32
+	files := http.FileServerFS(fstest.MapFS{
+		"aircraft_types.csv":    {Data: []byte("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n")},
+		"aircraft_families.csv": {Data: []byte("id,iata,icao,parent_family,level,name\n")},
+		"aircraft_aliases.csv":  {Data: []byte("alias,aircraft_type,aircraft_family\n")},
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		files.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	dir := filepath.Join(t.TempDir(), "cache")
+	for range 2 {
+		reg, err := CacheToDir(context.Background(), srv.URL, dir, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		if _, ok := reg.Type("320"); !ok {
+			t.Fatal("expected aircraft type 320")
+			return
+		}
+	}
+
+	if n := requests.Load(); n != 3 {
+		t.Fatalf("expected 3 requests for the first call only, got %d", n)
+		return
+	}
+
+	expired := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "aircraft_types.csv"), expired, expired); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err := CacheToDir(context.Background(), srv.URL, dir, time.Hour); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if n := requests.Load(); n != 6 {
+		t.Fatalf("expected all files to be fetched again once expired, got %d requests", n)
+		return
+	}
+}
+
+func TestCacheToDirFailedDownload(t *testing.T) {
+	var fail atomic.Bool
+	files := http.FileServerFS(fstest.MapFS{
+		"aircraft_types.csv":    {Data: []byte("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n")},
+		"aircraft_families.csv": {Data: []byte("id,iata,icao,parent_family,level,name\n")},
+		"aircraft_aliases.csv":  {Data: []byte("alias,aircraft_type,aircraft_family\n")},
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() && r.URL.Path == "/aircraft_aliases.csv" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		files.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	dir := filepath.Join(t.TempDir(), "cache")
+	if _, err := CacheToDir(context.Background(), srv.URL, dir, time.Hour); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	expired := time.Now().Add(-2 * time.Hour)
+	for _, name := range remoteFiles {
+		if err := os.Chtimes(filepath.Join(dir, name), expired, expired); err != nil {
+			t.Fatal(err)
+			return
+		}
+	}
+
+	fail.Store(true)
+	if _, err := CacheToDir(context.Background(), srv.URL, dir, time.Hour); err == nil {
+		t.Fatal("expected error for the failed download")
+		return
+	}
+
+	for _, name := range remoteFiles {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+
+		if time.Since(info.ModTime()) < time.Hour {
+			t.Fatalf("expected %s of the previous cache to be kept, got modification time %v", name, info.ModTime())
+			return
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(entries) != len(remoteFiles) {
+		t.Fatalf("expected only the cached files in %s, got %v", dir, entries)
+		return
+	}
+}
+
+func TestCacheToDirInvalidData(t *testing.T) {
+	var invalid atomic.Bool
+	files := http.FileServerFS(fstest.MapFS{
+		"aircraft_types.csv":    {Data: []byte("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n")},
+		"aircraft_families.csv": {Data: []byte("id,iata,icao,parent_family,level,name\n")},
+		"aircraft_aliases.csv":  {Data: []byte("alias,aircraft_type,aircraft_family\n")},
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if invalid.Load() && r.URL.Path == "/aircraft_types.csv" {
+			io.WriteString(w, "id,family_id,iata,icao,name\n320,,320,A320")
+			return
+		}
+
+		files.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	dir := filepath.Join(t.TempDir(), "cache")
+	if _, err := CacheToDir(context.Background(), srv.URL, dir, time.Hour); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	expired := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "aircraft_types.csv"), expired, expired); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	invalid.Store(true)
+	if _, err := CacheToDir(context.Background(), srv.URL, dir, time.Hour); err == nil {
+		t.Fatal("expected error for the truncated aircraft_types.csv")
+		return
+	}
+
+	reg, err := NewRegistryFromDir(dir)
+	if err != nil {
+		t.Fatalf("expected the previous cache to stay valid, got %v", err)
+		return
+	}
+
+	if _, ok := reg.Type("320"); !ok {
+		t.Fatal("expected aircraft type 320 in the previous cache")
+		return
+	}
+}
@@ -0,0 +1,203 @@
+package referencedata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// IATAConflict is an IATA code used by both an aircraft type and an aircraft family.
+type IATAConflict struct {
+	Code     IATA
+	TypeId   string
+	FamilyId string
+}
+
+// UniqueIATAConflictReport returns every pair of an aircraft type and an aircraft family sharing the same IATA code,
+// ordered by family and then type in file order.
+func (r *Registry) UniqueIATAConflictReport() []IATAConflict {
+	typesByIATA := make(map[IATA][]*AircraftType)
+	for _, t := range r.types {
+		typesByIATA[t.IATA] = append(typesByIATA[t.IATA], t)
+	}
+
+	var conflicts []IATAConflict
+	for _, f := range r.families {
+		if f.IATA == "" {
+			continue
+		}
+
+		for _, t := range typesByIATA[f.IATA] {
+			conflicts = append(conflicts, IATAConflict{Code: f.IATA, TypeId: t.Id, FamilyId: f.Id})
+		}
+	}
+
+	return conflicts
+}
+
+// ValidateEmbedded checks the columns of the embedded csv files and the consistency of the registry built from them.
+func ValidateEmbedded() error {
+	for _, file := range embeddedFiles {
+		if err := ValidateSchema(strings.NewReader(file.content), file.schema); err != nil {
+			return fmt.Errorf("%s: %w", file.name, err)
+		}
+	}
+
+	reg, err := NewRegistry()
+	if err != nil {
+		return err
+	}
+
+	return validateRegistry(reg)
+}
+
+// ValidationError is a data integrity problem of a single record which doesn't prevent building a Registry.
+type ValidationError struct {
+	// Table is the name of the csv file without extension, e.g. aircraft_types.
+	Table   string `json:"table"`
+	Id      string `json:"id"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s %q: %s", e.Table, e.Id, e.Message)
+}
+
+// LoadAndValidate builds a Registry like NewRegistryFromReaders and checks the consistency of its records.
+// The returned error is only non-nil if a file can't be parsed,
+// integrity problems are returned as ValidationErrors together with the registry.
+func LoadAndValidate(typesReader, familiesReader, aliasesReader io.Reader, opts ...RegistryOption) (*Registry, []ValidationError, error) {
+	reg, err := NewRegistryFromReaders(typesReader, familiesReader, aliasesReader, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return reg, reg.validationErrors(), nil
+}
+
+// validationErrors returns all consistency problems of r in the order of its tables and records.
+func (r *Registry) validationErrors() []ValidationError {
+	var res []ValidationError
+	for _, t := range r.types {
+		if _, ok := r.familyById[t.FamilyId]; t.FamilyId != "" && !ok {
+			res = append(res, ValidationError{Table: "aircraft_types", Id: t.Id, Message: fmt.Sprintf("family %q does not exist", t.FamilyId)})
+		}
+
+		if _, ok := r.typeById[t.SupersededBy]; t.SupersededBy != "" && !ok {
+			res = append(res, ValidationError{Table: "aircraft_types", Id: t.Id, Message: fmt.Sprintf("superseding aircraft type %q does not exist", t.SupersededBy)})
+		}
+	}
+
+	for _, c := range r.UniqueIATAConflictReport() {
+		res = append(res, ValidationError{Table: "aircraft_types", Id: c.TypeId, Message: fmt.Sprintf("iata %q is also used by aircraft family %q", c.Code, c.FamilyId)})
+	}
+
+	for _, f := range r.families {
+		if _, ok := r.familyById[f.ParentFamilyId]; f.ParentFamilyId != "" && !ok {
+			res = append(res, ValidationError{Table: "aircraft_families", Id: f.Id, Message: fmt.Sprintf("parent family %q does not exist", f.ParentFamilyId)})
+		} else if r.hasCyclicParents(f) {
+			res = append(res, ValidationError{Table: "aircraft_families", Id: f.Id, Message: "family is its own ancestor"})
+		}
+	}
+
+	for _, a := range r.aliases {
+		_, typeOk := r.typeById[a.AircraftTypeId]
+		_, familyOk := r.familyById[a.AircraftFamilyId]
+		switch {
+		case (a.AircraftTypeId == "") == (a.AircraftFamilyId == ""):
+			res = append(res, ValidationError{Table: "aircraft_aliases", Id: string(a.Alias), Message: "exactly one of aircraft_type and aircraft_family must be set"})
+		case a.AircraftTypeId != "" && !typeOk:
+			res = append(res, ValidationError{Table: "aircraft_aliases", Id: string(a.Alias), Message: fmt.Sprintf("aircraft type %q does not exist", a.AircraftTypeId)})
+		case a.AircraftFamilyId != "" && !familyOk:
+			res = append(res, ValidationError{Table: "aircraft_aliases", Id: string(a.Alias), Message: fmt.Sprintf("family %q does not exist", a.AircraftFamilyId)})
+		}
+	}
+
+	return res
+}
+
+// hasCyclicParents reports whether f is reached again by following its parent families.
+func (r *Registry) hasCyclicParents(f *AircraftFamily) bool {
+	visited := make(map[string]struct{})
+	for parent, ok := r.familyById[f.ParentFamilyId]; ok; parent, ok = r.familyById[parent.ParentFamilyId] {
+		if parent.Id == f.Id {
+			return true
+		} else if _, ok := visited[parent.Id]; ok {
+			return false
+		}
+
+		visited[parent.Id] = struct{}{}
+	}
+
+	return false
+}
+
+// validateRegistry reports all consistency problems of reg at once.
+func validateRegistry(reg *Registry) error {
+	var errs []error
+	for _, e := range reg.validationErrors() {
+		errs = append(errs, e)
+	}
+
+	return errors.Join(errs...)
+}
+
+// WarningSeverity is how likely an InconsistencyWarning is a data error.
+type WarningSeverity string
+
+const (
+	// WarningSeverityLow marks values which are unusual but plausible.
+	WarningSeverityLow WarningSeverity = "low"
+	// WarningSeverityHigh marks values which are almost certainly wrong.
+	WarningSeverityHigh WarningSeverity = "high"
+)
+
+// InconsistencyWarning is an anomaly of a single value of an aircraft type which is not a validation error.
+type InconsistencyWarning struct {
+	TypeId    string          `json:"typeId"`
+	FieldName string          `json:"fieldName"`
+	Value     string          `json:"value"`
+	Reason    string          `json:"reason"`
+	Severity  WarningSeverity `json:"severity"`
+}
+
+// InconsistencyReport returns the anomalies of all aircraft types of r in file order.
+// Unlike the ValidationErrors of LoadAndValidate they don't fail validation.
+func (r *Registry) InconsistencyReport() []InconsistencyWarning {
+	var res []InconsistencyWarning
+	for _, t := range r.types {
+		if t.BodyType == BodyTypeWidebody && t.TypicalSeats > 0 && t.TypicalSeats < 200 {
+			res = append(res, InconsistencyWarning{
+				TypeId:    t.Id,
+				FieldName: "typical_seats",
+				Value:     strconv.Itoa(t.TypicalSeats),
+				Reason:    "widebody with fewer than 200 seats",
+				Severity:  WarningSeverityLow,
+			})
+		}
+
+		if t.EngineType == EngineTypeTurboprop && t.EngineCount > 4 {
+			res = append(res, InconsistencyWarning{
+				TypeId:    t.Id,
+				FieldName: "engine_count",
+				Value:     strconv.Itoa(t.EngineCount),
+				Reason:    "turboprop with more than 4 engines",
+				Severity:  WarningSeverityHigh,
+			})
+		}
+
+		if t.BodyType == BodyTypeTurboprop && t.EngineType == EngineTypeJet {
+			res = append(res, InconsistencyWarning{
+				TypeId:    t.Id,
+				FieldName: "engine_type",
+				Value:     string(t.EngineType),
+				Reason:    "turboprop body type with jet engines",
+				Severity:  WarningSeverityHigh,
+			})
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,146 @@
+package referencedata
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestUniqueIATAConflictReport(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,name\n320,,320,A320,Airbus A320\n738,,738,B738,Boeing 737-800\n100,,100,F100,Fokker 100\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,320,,,family,Airbus A320\n737,738,,,family,Boeing 737\nF10,F10,,,family,Fokker\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	expected := []IATAConflict{
+		{Code: "320", TypeId: "320", FamilyId: "32S"},
+		{Code: "738", TypeId: "738", FamilyId: "737"},
+	}
+
+	if conflicts := reg.UniqueIATAConflictReport(); !slices.Equal(conflicts, expected) {
+		t.Fatalf("expected %v, got %v", expected, conflicts)
+		return
+	}
+
+	err = validateRegistry(reg)
+	if err == nil {
+		t.Fatal("expected validation to fail")
+		return
+	}
+
+	for _, code := range []string{`"320"`, `"738"`} {
+		if !strings.Contains(err.Error(), code) {
+			t.Fatalf("expected the conflict for %s to be reported, got %v", code, err)
+			return
+		}
+	}
+}
+
+func TestUniqueIATAConflictReportEmbedded(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if conflicts := reg.UniqueIATAConflictReport(); len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts in the embedded data, got %v", conflicts)
+		return
+	}
+}
+
+func TestLoadAndValidate(t *testing.T) {
+	reg, validationErrors, err := LoadAndValidate(
+		strings.NewReader("id,family_id,iata,icao,superseded_by,name\n320,32S,320,A320,,Airbus A320\n321,XXX,321,A321,32Q,Airbus A321\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n32S,32S,,,family,Airbus A320 Family\nAAA,,,BBB,family,A\nBBB,,,AAA,family,B\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n32A,320,\n32B,,\n32C,32Q,\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, ok := reg.Type("321"); !ok {
+		t.Fatal("expected the registry to contain the invalid records")
+		return
+	}
+
+	var ids []string
+	for _, e := range validationErrors {
+		ids = append(ids, e.Table+"/"+e.Id)
+	}
+
+	expected := []string{"aircraft_types/321", "aircraft_types/321", "aircraft_families/AAA", "aircraft_families/BBB", "aircraft_aliases/32B", "aircraft_aliases/32C"}
+	if !slices.Equal(ids, expected) {
+		t.Fatalf("expected validation errors for %v, got %v", expected, validationErrors)
+		return
+	}
+
+	if _, _, err = LoadAndValidate(
+		strings.NewReader("id,family_id,iata,icao,name\n320,,320,a320,Airbus A320\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	); err == nil {
+		t.Fatal("expected error for an unparseable file")
+		return
+	}
+}
+
+func TestLoadAndValidateEmbedded(t *testing.T) {
+	_, validationErrors, err := LoadAndValidate(strings.NewReader(types), strings.NewReader(families), strings.NewReader(aliases))
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(validationErrors) != 0 {
+		t.Fatalf("expected no validation errors in the embedded data, got %v", validationErrors)
+		return
+	}
+}
+
+func TestInconsistencyReport(t *testing.T) {
+	reg, err := newRegistry(
+		strings.NewReader("id,family_id,iata,icao,engine_count,engine_type,body_type,typical_seats,name\n"+
+			"310,,310,A310,2,Jet,widebody,180,Airbus A310\n"+
+			"332,,332,A332,2,Jet,widebody,250,Airbus A330-200\n"+
+			"AT7,,AT7,AT72,6,Turboprop/Turboshaft,turboprop,70,ATR 72\n"+
+			"DH8,,DH8,DH8A,2,Jet,turboprop,37,De Havilland Dash 8\n"),
+		strings.NewReader("id,iata,icao,parent_family,level,name\n"),
+		strings.NewReader("alias,aircraft_type,aircraft_family\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	expected := []InconsistencyWarning{
+		{TypeId: "310", FieldName: "typical_seats", Value: "180", Reason: "widebody with fewer than 200 seats", Severity: WarningSeverityLow},
+		{TypeId: "AT7", FieldName: "engine_count", Value: "6", Reason: "turboprop with more than 4 engines", Severity: WarningSeverityHigh},
+		{TypeId: "DH8", FieldName: "engine_type", Value: "Jet", Reason: "turboprop body type with jet engines", Severity: WarningSeverityHigh},
+	}
+
+	if warnings := reg.InconsistencyReport(); !slices.Equal(warnings, expected) {
+		t.Fatalf("expected %v, got %v", expected, warnings)
+		return
+	}
+}
+
+func TestInconsistencyReportEmbedded(t *testing.T) {
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	for _, w := range reg.InconsistencyReport() {
+		if w.Severity == WarningSeverityHigh {
+			t.Errorf("aircraft type %q: %s (%s = %q)", w.TypeId, w.Reason, w.FieldName, w.Value)
+		}
+	}
+}
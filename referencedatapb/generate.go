@@ -0,0 +1,4 @@
+// Package referencedatapb contains the protobuf messages and gRPC service of the reference data API.
+package referencedatapb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative reference_data.proto
@@ -0,0 +1,766 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: reference_data.proto
+
+package referencedatapb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AircraftType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FamilyId      string                 `protobuf:"bytes,2,opt,name=family_id,json=familyId,proto3" json:"family_id,omitempty"`
+	Iata          string                 `protobuf:"bytes,3,opt,name=iata,proto3" json:"iata,omitempty"`
+	Icao          string                 `protobuf:"bytes,4,opt,name=icao,proto3" json:"icao,omitempty"`
+	Wtc           string                 `protobuf:"bytes,5,opt,name=wtc,proto3" json:"wtc,omitempty"`
+	EngineCount   int32                  `protobuf:"varint,6,opt,name=engine_count,json=engineCount,proto3" json:"engine_count,omitempty"`
+	EngineType    string                 `protobuf:"bytes,7,opt,name=engine_type,json=engineType,proto3" json:"engine_type,omitempty"`
+	BodyType      string                 `protobuf:"bytes,8,opt,name=body_type,json=bodyType,proto3" json:"body_type,omitempty"`
+	Name          string                 `protobuf:"bytes,9,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AircraftType) Reset() {
+	*x = AircraftType{}
+	mi := &file_reference_data_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AircraftType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AircraftType) ProtoMessage() {}
+
+func (x *AircraftType) ProtoReflect() protoreflect.Message {
+	mi := &file_reference_data_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AircraftType.ProtoReflect.Descriptor instead.
+func (*AircraftType) Descriptor() ([]byte, []int) {
+	return file_reference_data_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AircraftType) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AircraftType) GetFamilyId() string {
+	if x != nil {
+		return x.FamilyId
+	}
+	return ""
+}
+
+func (x *AircraftType) GetIata() string {
+	if x != nil {
+		return x.Iata
+	}
+	return ""
+}
+
+func (x *AircraftType) GetIcao() string {
+	if x != nil {
+		return x.Icao
+	}
+	return ""
+}
+
+func (x *AircraftType) GetWtc() string {
+	if x != nil {
+		return x.Wtc
+	}
+	return ""
+}
+
+func (x *AircraftType) GetEngineCount() int32 {
+	if x != nil {
+		return x.EngineCount
+	}
+	return 0
+}
+
+func (x *AircraftType) GetEngineType() string {
+	if x != nil {
+		return x.EngineType
+	}
+	return ""
+}
+
+func (x *AircraftType) GetBodyType() string {
+	if x != nil {
+		return x.BodyType
+	}
+	return ""
+}
+
+func (x *AircraftType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type AircraftFamily struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Iata           string                 `protobuf:"bytes,2,opt,name=iata,proto3" json:"iata,omitempty"`
+	Icao           string                 `protobuf:"bytes,3,opt,name=icao,proto3" json:"icao,omitempty"`
+	ParentFamilyId string                 `protobuf:"bytes,4,opt,name=parent_family_id,json=parentFamilyId,proto3" json:"parent_family_id,omitempty"`
+	Level          string                 `protobuf:"bytes,5,opt,name=level,proto3" json:"level,omitempty"`
+	Name           string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AircraftFamily) Reset() {
+	*x = AircraftFamily{}
+	mi := &file_reference_data_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AircraftFamily) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AircraftFamily) ProtoMessage() {}
+
+func (x *AircraftFamily) ProtoReflect() protoreflect.Message {
+	mi := &file_reference_data_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AircraftFamily.ProtoReflect.Descriptor instead.
+func (*AircraftFamily) Descriptor() ([]byte, []int) {
+	return file_reference_data_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AircraftFamily) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AircraftFamily) GetIata() string {
+	if x != nil {
+		return x.Iata
+	}
+	return ""
+}
+
+func (x *AircraftFamily) GetIcao() string {
+	if x != nil {
+		return x.Icao
+	}
+	return ""
+}
+
+func (x *AircraftFamily) GetParentFamilyId() string {
+	if x != nil {
+		return x.ParentFamilyId
+	}
+	return ""
+}
+
+func (x *AircraftFamily) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *AircraftFamily) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type LookupByIATARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Iata          string                 `protobuf:"bytes,1,opt,name=iata,proto3" json:"iata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupByIATARequest) Reset() {
+	*x = LookupByIATARequest{}
+	mi := &file_reference_data_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupByIATARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupByIATARequest) ProtoMessage() {}
+
+func (x *LookupByIATARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reference_data_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupByIATARequest.ProtoReflect.Descriptor instead.
+func (*LookupByIATARequest) Descriptor() ([]byte, []int) {
+	return file_reference_data_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LookupByIATARequest) GetIata() string {
+	if x != nil {
+		return x.Iata
+	}
+	return ""
+}
+
+type LookupByIATAResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*LookupByIATAResponse_Type
+	//	*LookupByIATAResponse_Family
+	Result        isLookupByIATAResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupByIATAResponse) Reset() {
+	*x = LookupByIATAResponse{}
+	mi := &file_reference_data_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupByIATAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupByIATAResponse) ProtoMessage() {}
+
+func (x *LookupByIATAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reference_data_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupByIATAResponse.ProtoReflect.Descriptor instead.
+func (*LookupByIATAResponse) Descriptor() ([]byte, []int) {
+	return file_reference_data_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LookupByIATAResponse) GetResult() isLookupByIATAResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *LookupByIATAResponse) GetType() *AircraftType {
+	if x != nil {
+		if x, ok := x.Result.(*LookupByIATAResponse_Type); ok {
+			return x.Type
+		}
+	}
+	return nil
+}
+
+func (x *LookupByIATAResponse) GetFamily() *AircraftFamily {
+	if x != nil {
+		if x, ok := x.Result.(*LookupByIATAResponse_Family); ok {
+			return x.Family
+		}
+	}
+	return nil
+}
+
+type isLookupByIATAResponse_Result interface {
+	isLookupByIATAResponse_Result()
+}
+
+type LookupByIATAResponse_Type struct {
+	Type *AircraftType `protobuf:"bytes,1,opt,name=type,proto3,oneof"`
+}
+
+type LookupByIATAResponse_Family struct {
+	Family *AircraftFamily `protobuf:"bytes,2,opt,name=family,proto3,oneof"`
+}
+
+func (*LookupByIATAResponse_Type) isLookupByIATAResponse_Result() {}
+
+func (*LookupByIATAResponse_Family) isLookupByIATAResponse_Result() {}
+
+type LookupByICAORequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Icao          string                 `protobuf:"bytes,1,opt,name=icao,proto3" json:"icao,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupByICAORequest) Reset() {
+	*x = LookupByICAORequest{}
+	mi := &file_reference_data_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupByICAORequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupByICAORequest) ProtoMessage() {}
+
+func (x *LookupByICAORequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reference_data_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupByICAORequest.ProtoReflect.Descriptor instead.
+func (*LookupByICAORequest) Descriptor() ([]byte, []int) {
+	return file_reference_data_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LookupByICAORequest) GetIcao() string {
+	if x != nil {
+		return x.Icao
+	}
+	return ""
+}
+
+type LookupByICAOResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []*AircraftType        `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	Family        *AircraftFamily        `protobuf:"bytes,2,opt,name=family,proto3" json:"family,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupByICAOResponse) Reset() {
+	*x = LookupByICAOResponse{}
+	mi := &file_reference_data_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupByICAOResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupByICAOResponse) ProtoMessage() {}
+
+func (x *LookupByICAOResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reference_data_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupByICAOResponse.ProtoReflect.Descriptor instead.
+func (*LookupByICAOResponse) Descriptor() ([]byte, []int) {
+	return file_reference_data_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LookupByICAOResponse) GetTypes() []*AircraftType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *LookupByICAOResponse) GetFamily() *AircraftFamily {
+	if x != nil {
+		return x.Family
+	}
+	return nil
+}
+
+type ListFamilyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFamilyRequest) Reset() {
+	*x = ListFamilyRequest{}
+	mi := &file_reference_data_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFamilyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFamilyRequest) ProtoMessage() {}
+
+func (x *ListFamilyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reference_data_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFamilyRequest.ProtoReflect.Descriptor instead.
+func (*ListFamilyRequest) Descriptor() ([]byte, []int) {
+	return file_reference_data_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListFamilyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListFamilyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Family        *AircraftFamily        `protobuf:"bytes,1,opt,name=family,proto3" json:"family,omitempty"`
+	SubFamilies   []*AircraftFamily      `protobuf:"bytes,2,rep,name=sub_families,json=subFamilies,proto3" json:"sub_families,omitempty"`
+	Types         []*AircraftType        `protobuf:"bytes,3,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFamilyResponse) Reset() {
+	*x = ListFamilyResponse{}
+	mi := &file_reference_data_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFamilyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFamilyResponse) ProtoMessage() {}
+
+func (x *ListFamilyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reference_data_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFamilyResponse.ProtoReflect.Descriptor instead.
+func (*ListFamilyResponse) Descriptor() ([]byte, []int) {
+	return file_reference_data_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListFamilyResponse) GetFamily() *AircraftFamily {
+	if x != nil {
+		return x.Family
+	}
+	return nil
+}
+
+func (x *ListFamilyResponse) GetSubFamilies() []*AircraftFamily {
+	if x != nil {
+		return x.SubFamilies
+	}
+	return nil
+}
+
+func (x *ListFamilyResponse) GetTypes() []*AircraftType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type ListDescendantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDescendantsRequest) Reset() {
+	*x = ListDescendantsRequest{}
+	mi := &file_reference_data_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDescendantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDescendantsRequest) ProtoMessage() {}
+
+func (x *ListDescendantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reference_data_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDescendantsRequest.ProtoReflect.Descriptor instead.
+func (*ListDescendantsRequest) Descriptor() ([]byte, []int) {
+	return file_reference_data_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListDescendantsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListDescendantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Families      []*AircraftFamily      `protobuf:"bytes,1,rep,name=families,proto3" json:"families,omitempty"`
+	Types         []*AircraftType        `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDescendantsResponse) Reset() {
+	*x = ListDescendantsResponse{}
+	mi := &file_reference_data_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDescendantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDescendantsResponse) ProtoMessage() {}
+
+func (x *ListDescendantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reference_data_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDescendantsResponse.ProtoReflect.Descriptor instead.
+func (*ListDescendantsResponse) Descriptor() ([]byte, []int) {
+	return file_reference_data_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListDescendantsResponse) GetFamilies() []*AircraftFamily {
+	if x != nil {
+		return x.Families
+	}
+	return nil
+}
+
+func (x *ListDescendantsResponse) GetTypes() []*AircraftType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+var File_reference_data_proto protoreflect.FileDescriptor
+
+const file_reference_data_proto_rawDesc = "" +
+	"\n" +
+	"\x14reference_data.proto\x12\x10referencedata.v1\"\xea\x01\n" +
+	"\fAircraftType\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tfamily_id\x18\x02 \x01(\tR\bfamilyId\x12\x12\n" +
+	"\x04iata\x18\x03 \x01(\tR\x04iata\x12\x12\n" +
+	"\x04icao\x18\x04 \x01(\tR\x04icao\x12\x10\n" +
+	"\x03wtc\x18\x05 \x01(\tR\x03wtc\x12!\n" +
+	"\fengine_count\x18\x06 \x01(\x05R\vengineCount\x12\x1f\n" +
+	"\vengine_type\x18\a \x01(\tR\n" +
+	"engineType\x12\x1b\n" +
+	"\tbody_type\x18\b \x01(\tR\bbodyType\x12\x12\n" +
+	"\x04name\x18\t \x01(\tR\x04name\"\x9c\x01\n" +
+	"\x0eAircraftFamily\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04iata\x18\x02 \x01(\tR\x04iata\x12\x12\n" +
+	"\x04icao\x18\x03 \x01(\tR\x04icao\x12(\n" +
+	"\x10parent_family_id\x18\x04 \x01(\tR\x0eparentFamilyId\x12\x14\n" +
+	"\x05level\x18\x05 \x01(\tR\x05level\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\")\n" +
+	"\x13LookupByIATARequest\x12\x12\n" +
+	"\x04iata\x18\x01 \x01(\tR\x04iata\"\x92\x01\n" +
+	"\x14LookupByIATAResponse\x124\n" +
+	"\x04type\x18\x01 \x01(\v2\x1e.referencedata.v1.AircraftTypeH\x00R\x04type\x12:\n" +
+	"\x06family\x18\x02 \x01(\v2 .referencedata.v1.AircraftFamilyH\x00R\x06familyB\b\n" +
+	"\x06result\")\n" +
+	"\x13LookupByICAORequest\x12\x12\n" +
+	"\x04icao\x18\x01 \x01(\tR\x04icao\"\x86\x01\n" +
+	"\x14LookupByICAOResponse\x124\n" +
+	"\x05types\x18\x01 \x03(\v2\x1e.referencedata.v1.AircraftTypeR\x05types\x128\n" +
+	"\x06family\x18\x02 \x01(\v2 .referencedata.v1.AircraftFamilyR\x06family\"#\n" +
+	"\x11ListFamilyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xc9\x01\n" +
+	"\x12ListFamilyResponse\x128\n" +
+	"\x06family\x18\x01 \x01(\v2 .referencedata.v1.AircraftFamilyR\x06family\x12C\n" +
+	"\fsub_families\x18\x02 \x03(\v2 .referencedata.v1.AircraftFamilyR\vsubFamilies\x124\n" +
+	"\x05types\x18\x03 \x03(\v2\x1e.referencedata.v1.AircraftTypeR\x05types\"(\n" +
+	"\x16ListDescendantsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8d\x01\n" +
+	"\x17ListDescendantsResponse\x12<\n" +
+	"\bfamilies\x18\x01 \x03(\v2 .referencedata.v1.AircraftFamilyR\bfamilies\x124\n" +
+	"\x05types\x18\x02 \x03(\v2\x1e.referencedata.v1.AircraftTypeR\x05types2\x95\x03\n" +
+	"\x14ReferenceDataService\x12]\n" +
+	"\fLookupByIATA\x12%.referencedata.v1.LookupByIATARequest\x1a&.referencedata.v1.LookupByIATAResponse\x12]\n" +
+	"\fLookupByICAO\x12%.referencedata.v1.LookupByICAORequest\x1a&.referencedata.v1.LookupByICAOResponse\x12W\n" +
+	"\n" +
+	"ListFamily\x12#.referencedata.v1.ListFamilyRequest\x1a$.referencedata.v1.ListFamilyResponse\x12f\n" +
+	"\x0fListDescendants\x12(.referencedata.v1.ListDescendantsRequest\x1a).referencedata.v1.ListDescendantsResponseB;Z9github.com/explore-flights/reference-data/referencedatapbb\x06proto3"
+
+var (
+	file_reference_data_proto_rawDescOnce sync.Once
+	file_reference_data_proto_rawDescData []byte
+)
+
+func file_reference_data_proto_rawDescGZIP() []byte {
+	file_reference_data_proto_rawDescOnce.Do(func() {
+		file_reference_data_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reference_data_proto_rawDesc), len(file_reference_data_proto_rawDesc)))
+	})
+	return file_reference_data_proto_rawDescData
+}
+
+var file_reference_data_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_reference_data_proto_goTypes = []any{
+	(*AircraftType)(nil),            // 0: referencedata.v1.AircraftType
+	(*AircraftFamily)(nil),          // 1: referencedata.v1.AircraftFamily
+	(*LookupByIATARequest)(nil),     // 2: referencedata.v1.LookupByIATARequest
+	(*LookupByIATAResponse)(nil),    // 3: referencedata.v1.LookupByIATAResponse
+	(*LookupByICAORequest)(nil),     // 4: referencedata.v1.LookupByICAORequest
+	(*LookupByICAOResponse)(nil),    // 5: referencedata.v1.LookupByICAOResponse
+	(*ListFamilyRequest)(nil),       // 6: referencedata.v1.ListFamilyRequest
+	(*ListFamilyResponse)(nil),      // 7: referencedata.v1.ListFamilyResponse
+	(*ListDescendantsRequest)(nil),  // 8: referencedata.v1.ListDescendantsRequest
+	(*ListDescendantsResponse)(nil), // 9: referencedata.v1.ListDescendantsResponse
+}
+var file_reference_data_proto_depIdxs = []int32{
+	0,  // 0: referencedata.v1.LookupByIATAResponse.type:type_name -> referencedata.v1.AircraftType
+	1,  // 1: referencedata.v1.LookupByIATAResponse.family:type_name -> referencedata.v1.AircraftFamily
+	0,  // 2: referencedata.v1.LookupByICAOResponse.types:type_name -> referencedata.v1.AircraftType
+	1,  // 3: referencedata.v1.LookupByICAOResponse.family:type_name -> referencedata.v1.AircraftFamily
+	1,  // 4: referencedata.v1.ListFamilyResponse.family:type_name -> referencedata.v1.AircraftFamily
+	1,  // 5: referencedata.v1.ListFamilyResponse.sub_families:type_name -> referencedata.v1.AircraftFamily
+	0,  // 6: referencedata.v1.ListFamilyResponse.types:type_name -> referencedata.v1.AircraftType
+	1,  // 7: referencedata.v1.ListDescendantsResponse.families:type_name -> referencedata.v1.AircraftFamily
+	0,  // 8: referencedata.v1.ListDescendantsResponse.types:type_name -> referencedata.v1.AircraftType
+	2,  // 9: referencedata.v1.ReferenceDataService.LookupByIATA:input_type -> referencedata.v1.LookupByIATARequest
+	4,  // 10: referencedata.v1.ReferenceDataService.LookupByICAO:input_type -> referencedata.v1.LookupByICAORequest
+	6,  // 11: referencedata.v1.ReferenceDataService.ListFamily:input_type -> referencedata.v1.ListFamilyRequest
+	8,  // 12: referencedata.v1.ReferenceDataService.ListDescendants:input_type -> referencedata.v1.ListDescendantsRequest
+	3,  // 13: referencedata.v1.ReferenceDataService.LookupByIATA:output_type -> referencedata.v1.LookupByIATAResponse
+	5,  // 14: referencedata.v1.ReferenceDataService.LookupByICAO:output_type -> referencedata.v1.LookupByICAOResponse
+	7,  // 15: referencedata.v1.ReferenceDataService.ListFamily:output_type -> referencedata.v1.ListFamilyResponse
+	9,  // 16: referencedata.v1.ReferenceDataService.ListDescendants:output_type -> referencedata.v1.ListDescendantsResponse
+	13, // [13:17] is the sub-list for method output_type
+	9,  // [9:13] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_reference_data_proto_init() }
+func file_reference_data_proto_init() {
+	if File_reference_data_proto != nil {
+		return
+	}
+	file_reference_data_proto_msgTypes[3].OneofWrappers = []any{
+		(*LookupByIATAResponse_Type)(nil),
+		(*LookupByIATAResponse_Family)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reference_data_proto_rawDesc), len(file_reference_data_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_reference_data_proto_goTypes,
+		DependencyIndexes: file_reference_data_proto_depIdxs,
+		MessageInfos:      file_reference_data_proto_msgTypes,
+	}.Build()
+	File_reference_data_proto = out.File
+	file_reference_data_proto_goTypes = nil
+	file_reference_data_proto_depIdxs = nil
+}
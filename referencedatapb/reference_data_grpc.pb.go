@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: reference_data.proto
+
+package referencedatapb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReferenceDataService_LookupByIATA_FullMethodName    = "/referencedata.v1.ReferenceDataService/LookupByIATA"
+	ReferenceDataService_LookupByICAO_FullMethodName    = "/referencedata.v1.ReferenceDataService/LookupByICAO"
+	ReferenceDataService_ListFamily_FullMethodName      = "/referencedata.v1.ReferenceDataService/ListFamily"
+	ReferenceDataService_ListDescendants_FullMethodName = "/referencedata.v1.ReferenceDataService/ListDescendants"
+)
+
+// ReferenceDataServiceClient is the client API for ReferenceDataService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReferenceDataServiceClient interface {
+	LookupByIATA(ctx context.Context, in *LookupByIATARequest, opts ...grpc.CallOption) (*LookupByIATAResponse, error)
+	LookupByICAO(ctx context.Context, in *LookupByICAORequest, opts ...grpc.CallOption) (*LookupByICAOResponse, error)
+	ListFamily(ctx context.Context, in *ListFamilyRequest, opts ...grpc.CallOption) (*ListFamilyResponse, error)
+	ListDescendants(ctx context.Context, in *ListDescendantsRequest, opts ...grpc.CallOption) (*ListDescendantsResponse, error)
+}
+
+type referenceDataServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReferenceDataServiceClient(cc grpc.ClientConnInterface) ReferenceDataServiceClient {
+	return &referenceDataServiceClient{cc}
+}
+
+func (c *referenceDataServiceClient) LookupByIATA(ctx context.Context, in *LookupByIATARequest, opts ...grpc.CallOption) (*LookupByIATAResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupByIATAResponse)
+	err := c.cc.Invoke(ctx, ReferenceDataService_LookupByIATA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referenceDataServiceClient) LookupByICAO(ctx context.Context, in *LookupByICAORequest, opts ...grpc.CallOption) (*LookupByICAOResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupByICAOResponse)
+	err := c.cc.Invoke(ctx, ReferenceDataService_LookupByICAO_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referenceDataServiceClient) ListFamily(ctx context.Context, in *ListFamilyRequest, opts ...grpc.CallOption) (*ListFamilyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFamilyResponse)
+	err := c.cc.Invoke(ctx, ReferenceDataService_ListFamily_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referenceDataServiceClient) ListDescendants(ctx context.Context, in *ListDescendantsRequest, opts ...grpc.CallOption) (*ListDescendantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDescendantsResponse)
+	err := c.cc.Invoke(ctx, ReferenceDataService_ListDescendants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReferenceDataServiceServer is the server API for ReferenceDataService service.
+// All implementations must embed UnimplementedReferenceDataServiceServer
+// for forward compatibility.
+type ReferenceDataServiceServer interface {
+	LookupByIATA(context.Context, *LookupByIATARequest) (*LookupByIATAResponse, error)
+	LookupByICAO(context.Context, *LookupByICAORequest) (*LookupByICAOResponse, error)
+	ListFamily(context.Context, *ListFamilyRequest) (*ListFamilyResponse, error)
+	ListDescendants(context.Context, *ListDescendantsRequest) (*ListDescendantsResponse, error)
+	mustEmbedUnimplementedReferenceDataServiceServer()
+}
+
+// UnimplementedReferenceDataServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReferenceDataServiceServer struct{}
+
+func (UnimplementedReferenceDataServiceServer) LookupByIATA(context.Context, *LookupByIATARequest) (*LookupByIATAResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LookupByIATA not implemented")
+}
+func (UnimplementedReferenceDataServiceServer) LookupByICAO(context.Context, *LookupByICAORequest) (*LookupByICAOResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LookupByICAO not implemented")
+}
+func (UnimplementedReferenceDataServiceServer) ListFamily(context.Context, *ListFamilyRequest) (*ListFamilyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFamily not implemented")
+}
+func (UnimplementedReferenceDataServiceServer) ListDescendants(context.Context, *ListDescendantsRequest) (*ListDescendantsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDescendants not implemented")
+}
+func (UnimplementedReferenceDataServiceServer) mustEmbedUnimplementedReferenceDataServiceServer() {}
+func (UnimplementedReferenceDataServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeReferenceDataServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReferenceDataServiceServer will
+// result in compilation errors.
+type UnsafeReferenceDataServiceServer interface {
+	mustEmbedUnimplementedReferenceDataServiceServer()
+}
+
+func RegisterReferenceDataServiceServer(s grpc.ServiceRegistrar, srv ReferenceDataServiceServer) {
+	// If the following call panics, it indicates UnimplementedReferenceDataServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReferenceDataService_ServiceDesc, srv)
+}
+
+func _ReferenceDataService_LookupByIATA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupByIATARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferenceDataServiceServer).LookupByIATA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferenceDataService_LookupByIATA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferenceDataServiceServer).LookupByIATA(ctx, req.(*LookupByIATARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferenceDataService_LookupByICAO_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupByICAORequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferenceDataServiceServer).LookupByICAO(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferenceDataService_LookupByICAO_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferenceDataServiceServer).LookupByICAO(ctx, req.(*LookupByICAORequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferenceDataService_ListFamily_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFamilyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferenceDataServiceServer).ListFamily(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferenceDataService_ListFamily_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferenceDataServiceServer).ListFamily(ctx, req.(*ListFamilyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferenceDataService_ListDescendants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDescendantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferenceDataServiceServer).ListDescendants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferenceDataService_ListDescendants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferenceDataServiceServer).ListDescendants(ctx, req.(*ListDescendantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReferenceDataService_ServiceDesc is the grpc.ServiceDesc for ReferenceDataService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReferenceDataService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "referencedata.v1.ReferenceDataService",
+	HandlerType: (*ReferenceDataServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LookupByIATA",
+			Handler:    _ReferenceDataService_LookupByIATA_Handler,
+		},
+		{
+			MethodName: "LookupByICAO",
+			Handler:    _ReferenceDataService_LookupByICAO_Handler,
+		},
+		{
+			MethodName: "ListFamily",
+			Handler:    _ReferenceDataService_ListFamily_Handler,
+		},
+		{
+			MethodName: "ListDescendants",
+			Handler:    _ReferenceDataService_ListDescendants_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reference_data.proto",
+}